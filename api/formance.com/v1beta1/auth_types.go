@@ -21,9 +21,42 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	OIDCProviderKeycloak = "keycloak"
+	OIDCProviderDex      = "dex"
+	OIDCProviderAzureAD  = "azure-ad"
+	OIDCProviderGoogle   = "google"
+)
+
+// OIDCProviderPreset allow to configure a well known OIDC provider without knowing its issuer, scopes and claims
+type OIDCProviderPreset struct {
+	//+kubebuilder:validation:Enum:={keycloak, dex, azure-ad, google}
+	// Type is the type of the provider
+	Type string `json:"type"`
+	//+optional
+	// URL is the base url of the provider, required for keycloak and dex
+	URL string `json:"url,omitempty"`
+	//+optional
+	// Realm is the keycloak realm
+	Realm string `json:"realm,omitempty"`
+	//+optional
+	// TenantID is the azure AD tenant
+	TenantID string `json:"tenantID,omitempty"`
+}
+
 type DelegatedOIDCServerConfiguration struct {
+	//+optional
+	// Preset allow to use the defaults of a well known provider.
+	// The issuer, scopes and email claim are deduced from the preset when not defined.
+	Preset *OIDCProviderPreset `json:"preset,omitempty"`
 	// Issuer is the url of the delegated oidc server
 	Issuer string `json:"issuer,omitempty"`
+	//+optional
+	// Scopes are the scopes requested to the delegated oidc server
+	Scopes []string `json:"scopes,omitempty"`
+	//+optional
+	// EmailClaim is the claim of the id token containing the email of the user
+	EmailClaim string `json:"emailClaim,omitempty"`
 	// ClientID is the client id to use for authentication
 	ClientID string `json:"clientID,omitempty"`
 	// ClientSecret is the client secret to use for authentication
@@ -33,6 +66,27 @@ type DelegatedOIDCServerConfiguration struct {
 	ClientSecretFromSecret *v1.SecretKeySelector `json:"clientSecretFromSecret,omitempty"`
 }
 
+type SigningKeyRotation struct {
+	// Interval is the duration between two rotations of the signing key (ex: 2160h)
+	Interval metav1.Duration `json:"interval"`
+	//+optional
+	// GracePeriod is the duration during which the previous key is still published after a rotation,
+	// so the tokens signed with it can still be validated (ex: 24h).
+	// It must be lower than the interval, and requires auth >= v3.1.0-alpha.
+	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+type SigningKeyStatus struct {
+	// Algorithm is the algorithm of the current signing key
+	Algorithm string `json:"algorithm"`
+	//+optional
+	// LastRotation is the date of the generation of the current signing key
+	LastRotation *metav1.Time `json:"lastRotation,omitempty"`
+	//+optional
+	// PreviousKeyExpiresAt is the date when the previous signing key will be retired, if any
+	PreviousKeyExpiresAt *metav1.Time `json:"previousKeyExpiresAt,omitempty"`
+}
+
 type AuthSpec struct {
 	ModuleProperties `json:",inline"`
 	StackDependency  `json:",inline"`
@@ -46,6 +100,17 @@ type AuthSpec struct {
 	// Allow to override the default signing key used to sign JWT tokens using a k8s secret
 	SigningKeyFromSecret *v1.SecretKeySelector `json:"signingKeyFromSecret,omitempty"`
 	//+optional
+	//+kubebuilder:validation:Enum:={RS256, ES256}
+	// SigningKeyAlgorithm is the algorithm of the signing key generated by the operator
+	// when no signing key is provided (RS256 if not defined).
+	// The operator generates the signing key only if the algorithm or the rotation is defined.
+	// ES256 requires auth >= v3.1.0-alpha.
+	SigningKeyAlgorithm string `json:"signingKeyAlgorithm,omitempty"`
+	//+optional
+	// SigningKeyRotation allow to periodically rotate the signing key generated by the operator.
+	// It is ignored if a signing key is provided.
+	SigningKeyRotation *SigningKeyRotation `json:"signingKeyRotation,omitempty"`
+	//+optional
 	// Allow to enable scopes usage on authentication.
 	//
 	// If not enabled, each service will check the authentication but will not restrict access following scopes.
@@ -59,6 +124,13 @@ type AuthStatus struct {
 	//+optional
 	// Clients contains the list of clients created using [AuthClient](#authclient)
 	Clients []string `json:"clients"`
+	//+optional
+	// SigningKey contains the state of the signing key generated by the operator, if any
+	SigningKey *SigningKeyStatus `json:"signingKey,omitempty"`
+	//+optional
+	// ClientSecretHashes contains the hash of the secrets of the rotated clients, as served by the rolled out auth server.
+	// The consumers of a client are restarted with a new secret only once the auth server accepts it.
+	ClientSecretHashes map[string]string `json:"clientSecretHashes,omitempty"`
 }
 
 // Auth represent the authentication module of a stack.
@@ -71,6 +143,7 @@ type AuthStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Clients",type=string,JSONPath=".status.clients",description="Synchronized auth clients"
 // +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
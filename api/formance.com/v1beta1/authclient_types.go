@@ -52,6 +52,20 @@ type AuthClientSpec struct {
 
 	// +optional
 	SecretFromSecret *v1.SecretKeySelector `json:"secretFromSecret,omitempty" yaml:"-"`
+
+	// +optional
+	// Rotation allow to periodically rotate the secret of the client.
+	// When enabled, the secrets are generated by the operator, .spec.secret is only used as the initial secret.
+	Rotation *AuthClientRotation `json:"rotation,omitempty" yaml:"-"`
+}
+
+type AuthClientRotation struct {
+	// Interval is the duration between two rotations of the secret (ex: 720h)
+	Interval metav1.Duration `json:"interval"`
+	// +optional
+	// Overlap is the duration during which the previous secret is still accepted after a rotation (ex: 1h).
+	// It must be lower than the interval.
+	Overlap metav1.Duration `json:"overlap,omitempty"`
 }
 
 var _ yaml.Marshaler = (*AuthClientSpec)(nil)
@@ -77,6 +91,12 @@ type AuthClientStatus struct {
 
 	//+optional
 	Hash string `json:"hash,omitempty"`
+	//+optional
+	// LastRotation is the date of the last rotation of the secret
+	LastRotation *metav1.Time `json:"lastRotation,omitempty"`
+	//+optional
+	// PreviousSecretExpiresAt is the date when the previous secret will be retired, if any
+	PreviousSecretExpiresAt *metav1.Time `json:"previousSecretExpiresAt,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,13 @@
+package v1beta1
+
+// The v1beta1 version is the storage version of the objects, and the version used by the reconcilers.
+// The objects available in other versions implement the conversion from and to it.
+
+// Hub marks this type as a conversion hub.
+func (*Stack) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Stargate) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Auth) Hub() {}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CustomModuleBrokerConfig struct {
+	// +optional
+	// Services whose events are consumed by the module.
+	// The topics are given to the module with the env var BROKER_TOPICS.
+	Consumes []string `json:"consumes,omitempty"`
+	// +optional
+	// Publish configures the module to publish its events, on a topic named after the module.
+	// The publisher is configured only when another module consumes the events of the module.
+	// +kubebuilder:default:=false
+	Publish bool `json:"publish"`
+}
+
+type CustomModuleAuthClientConfig struct {
+	// +optional
+	// Scopes granted to the client
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type CustomModuleGatewayConfig struct {
+	// +optional
+	// Rules of the routes exposed on the gateway, all the routes are secured by default
+	Rules []GatewayHTTPAPIRule `json:"rules,omitempty"`
+	// +optional
+	// HealthCheckEndpoint is the path of the health check of the module, used by the gateway
+	HealthCheckEndpoint string `json:"healthCheckEndpoint,omitempty"`
+}
+
+type CustomModuleSpec struct {
+	DevProperties   `json:",inline"`
+	StackDependency `json:",inline"`
+	// Name of the module, used to name the deployment, the service and the database of the module,
+	// and as prefix of the routes on the gateway.
+	// It must not be used by a module of the operator, or by another custom module of the stack.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Image of the module, including the tag
+	Image string `json:"image"`
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// +optional
+	// Port on which the module listens for HTTP requests
+	// +kubebuilder:default:=8080
+	Port int32 `json:"port,omitempty"`
+	// +optional
+	// HealthCheckPath is the path used by the liveness and readiness probes, no probes are configured if empty
+	HealthCheckPath string `json:"healthCheckPath,omitempty"`
+	// +optional
+	// Database creates a database for the module.
+	// The connection is given to the module with the env vars POSTGRES_*.
+	// +kubebuilder:default:=false
+	Database bool `json:"database"`
+	// +optional
+	// Broker connects the module to the broker of the stack
+	Broker *CustomModuleBrokerConfig `json:"broker,omitempty"`
+	// +optional
+	// AuthClient creates an auth client for the module.
+	// The credentials are given to the module with the env vars STACK_CLIENT_ID and STACK_CLIENT_SECRET.
+	AuthClient *CustomModuleAuthClientConfig `json:"authClient,omitempty"`
+	// +optional
+	// Gateway exposes the module on the gateway of the stack
+	Gateway *CustomModuleGatewayConfig `json:"gateway,omitempty"`
+}
+
+// CustomModuleStatus defines the observed state of CustomModule
+type CustomModuleStatus struct {
+	Status `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=".spec.name",description="Name"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=".spec.image",description="Image"
+// +kubebuilder:metadata:labels=formance.com/kind=module
+// CustomModule deploys an arbitrary service on a stack
+type CustomModule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomModuleSpec   `json:"spec,omitempty"`
+	Status CustomModuleStatus `json:"status,omitempty"`
+}
+
+func (in *CustomModule) IsEE() bool {
+	return false
+}
+
+func (in *CustomModule) SetReady(b bool) {
+	in.Status.Ready = b
+}
+
+func (in *CustomModule) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *CustomModule) SetError(s string) {
+	in.Status.Info = s
+}
+
+func (in *CustomModule) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+// GetVersion returns an empty version, the version of a custom module is defined by its image
+func (in *CustomModule) GetVersion() string {
+	return ""
+}
+
+func (a CustomModule) GetStack() string {
+	return a.Spec.Stack
+}
+
+func (a CustomModule) IsDebug() bool {
+	return a.Spec.Debug
+}
+
+func (a CustomModule) IsDev() bool {
+	return a.Spec.Dev
+}
+
+//+kubebuilder:object:root=true
+
+// CustomModuleList contains a list of CustomModule
+type CustomModuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomModule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CustomModule{}, &CustomModuleList{})
+}
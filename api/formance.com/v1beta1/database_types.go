@@ -28,6 +28,10 @@ type DatabaseSpec struct {
 	Service string `json:"service"`
 	// +kubebuilder:default:=false
 	Debug bool `json:"debug,omitempty"`
+	//+optional
+	// CloneFrom is the name of a Database object whose content is copied into the database on creation.
+	// It is set by a [StackClone](#stackclone) requesting the copy of the databases.
+	CloneFrom string `json:"cloneFrom,omitempty"`
 }
 
 type DatabaseStatus struct {
@@ -63,6 +67,9 @@ type DatabaseStatus struct {
 //
 // Therefore, to switch to a new server, you must change the setting value, then drop the Database object.
 // It will be recreated with correct uri.
+//
+// When `.spec.cloneFrom` is defined, the content of the source database is copied, using `pg_dump` and `psql`,
+// once the database is created. The database is not marked as ready until the copy is complete.
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
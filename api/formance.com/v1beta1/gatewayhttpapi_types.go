@@ -20,6 +20,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+type GatewayHTTPAPIClaimMatcher struct {
+	//+kubebuilder:validation:Pattern=`^[A-Za-z0-9_]+$`
+	// Claim is the name of the claim of the JWT token
+	Claim string `json:"claim"`
+	// Values are the accepted values of the claim
+	Values []string `json:"values"`
+}
+
 type GatewayHTTPAPIRule struct {
 	Path string `json:"path"`
 	//+optional
@@ -27,6 +35,14 @@ type GatewayHTTPAPIRule struct {
 	//+optional
 	//+kubebuilder:default:=false
 	Secured bool `json:"secured"`
+	//+optional
+	// Scopes are the scopes required on the JWT token to access the route.
+	// They are enforced by the gateway only if the stack has an Auth module.
+	Scopes []string `json:"scopes,omitempty"`
+	//+optional
+	// Claims are matchers on the claims of the JWT token, all of them must match to access the route.
+	// They are enforced by the gateway only if the stack has an Auth module.
+	Claims []GatewayHTTPAPIClaimMatcher `json:"claims,omitempty"`
 }
 
 type GatewayHTTPAPISpec struct {
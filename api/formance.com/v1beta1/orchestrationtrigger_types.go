@@ -0,0 +1,117 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type OrchestrationTriggerSpec struct {
+	StackDependency `json:",inline"`
+	//+optional
+	// Name of the trigger on the orchestration API, if not provided, the name of the resource is used
+	Name string `json:"name,omitempty"`
+	// Event is the type of the event starting the workflow (SAVED_PAYMENT, COMMITTED_TRANSACTIONS, ...)
+	Event string `json:"event"`
+	// Workflow is the name of the OrchestrationWorkflow resource to start
+	Workflow string `json:"workflow"`
+	//+optional
+	// Filter is an expression evaluated on the event, the workflow is started only if it evaluates to true
+	Filter string `json:"filter,omitempty"`
+	//+optional
+	// Vars are the variables passed to the workflow, values are expressions evaluated on the event
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+type OrchestrationTriggerStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// TriggerID is the id of the trigger on the orchestration API
+	TriggerID string `json:"triggerID,omitempty"`
+	//+optional
+	// WorkflowID is the id of the workflow version started by the trigger
+	WorkflowID string `json:"workflowID,omitempty"`
+	//+optional
+	// ConfigHash is the hash of the configuration last pushed to the orchestration API
+	ConfigHash string `json:"configHash,omitempty"`
+	//+optional
+	// PendingDeletion are the ids of the replaced triggers which remain to be deleted on the orchestration API
+	PendingDeletion []string `json:"pendingDeletion,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Event",type=string,JSONPath=".spec.event",description="Event"
+// +kubebuilder:printcolumn:name="Workflow",type=string,JSONPath=".spec.workflow",description="Workflow"
+// +kubebuilder:printcolumn:name="Trigger ID",type=string,JSONPath=".status.triggerID",description="Trigger ID"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// OrchestrationTrigger declares a trigger starting an OrchestrationWorkflow when an event is received
+// by the orchestration module.
+//
+// Triggers are immutable on the orchestration API, so each change of the spec, or of the workflow version,
+// creates a new trigger, and the previous one is deleted.
+type OrchestrationTrigger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrchestrationTriggerSpec   `json:"spec,omitempty"`
+	Status OrchestrationTriggerStatus `json:"status,omitempty"`
+}
+
+func (in *OrchestrationTrigger) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *OrchestrationTrigger) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *OrchestrationTrigger) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *OrchestrationTrigger) GetStack() string {
+	return in.Spec.Stack
+}
+
+func (in *OrchestrationTrigger) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+func (in *OrchestrationTrigger) GetTriggerName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+	return in.Name
+}
+
+//+kubebuilder:object:root=true
+
+// OrchestrationTriggerList contains a list of OrchestrationTrigger
+type OrchestrationTriggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrchestrationTrigger `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrchestrationTrigger{}, &OrchestrationTriggerList{})
+}
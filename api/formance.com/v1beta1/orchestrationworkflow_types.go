@@ -0,0 +1,109 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type OrchestrationWorkflowSpec struct {
+	StackDependency `json:",inline"`
+	//+optional
+	// Name of the workflow on the orchestration API, if not provided, the name of the resource is used
+	Name string `json:"name,omitempty"`
+	// Definition is the workflow definition, in yaml, as accepted by the orchestration v2 API.
+	// It must contain at least the stages of the workflow.
+	Definition string `json:"definition"`
+}
+
+type OrchestrationWorkflowStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// WorkflowID is the id of the current version of the workflow on the orchestration API
+	WorkflowID string `json:"workflowID,omitempty"`
+	//+optional
+	// Version is incremented each time a new definition is pushed to the orchestration API
+	Version int `json:"version,omitempty"`
+	//+optional
+	// DefinitionHash is the hash of the definition last pushed to the orchestration API
+	DefinitionHash string `json:"definitionHash,omitempty"`
+	//+optional
+	// PendingDeletion are the ids of the previous versions of the workflow which remain to be deleted on the orchestration API
+	PendingDeletion []string `json:"pendingDeletion,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Workflow ID",type=string,JSONPath=".status.workflowID",description="Workflow ID"
+// +kubebuilder:printcolumn:name="Version",type=integer,JSONPath=".status.version",description="Version"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// OrchestrationWorkflow declares a workflow created on the orchestration module of a stack.
+//
+// Workflows are immutable on the orchestration API, so each change of the definition creates a new version
+// of the workflow, and the previous version is deleted.
+// The workflow is deleted from the orchestration API when the resource is removed.
+type OrchestrationWorkflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrchestrationWorkflowSpec   `json:"spec,omitempty"`
+	Status OrchestrationWorkflowStatus `json:"status,omitempty"`
+}
+
+func (in *OrchestrationWorkflow) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *OrchestrationWorkflow) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *OrchestrationWorkflow) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *OrchestrationWorkflow) GetStack() string {
+	return in.Spec.Stack
+}
+
+func (in *OrchestrationWorkflow) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+func (in *OrchestrationWorkflow) GetWorkflowName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+	return in.Name
+}
+
+//+kubebuilder:object:root=true
+
+// OrchestrationWorkflowList contains a list of OrchestrationWorkflow
+type OrchestrationWorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrchestrationWorkflow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrchestrationWorkflow{}, &OrchestrationWorkflowList{})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PaymentsConnectorSpec struct {
+	StackDependency `json:",inline"`
+	// Provider is the connector provider as known by the payments API (stripe, wise, modulr, ...)
+	Provider string `json:"provider"`
+	//+optional
+	// Name of the connector on the payments API, if not provided, the name of the resource is used
+	Name string `json:"name,omitempty"`
+	//+optional
+	// Config contains the provider specific configuration (pollingPeriod, pageSize, endpoint, ...)
+	Config map[string]apiextensionsv1.JSON `json:"config,omitempty"`
+	//+optional
+	// Credentials contains provider specific configuration fields loaded from secrets (apiKey, clientSecret, ...)
+	// The secrets must be labeled with formance.com/stack=<stack>|any, like any other secret used by the operator
+	Credentials map[string]v1.SecretKeySelector `json:"credentials,omitempty"`
+}
+
+type PaymentsConnectorStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// ConnectorID is the id of the connector on the payments API
+	ConnectorID string `json:"connectorID,omitempty"`
+	//+optional
+	// ConfigHash is the hash of the configuration last applied on the payments API
+	ConfigHash string `json:"configHash,omitempty"`
+	//+optional
+	// LastPollTime is the last time the connector polled the provider
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=".spec.provider",description="Provider"
+// +kubebuilder:printcolumn:name="Connector ID",type=string,JSONPath=".status.connectorID",description="Connector ID"
+// +kubebuilder:printcolumn:name="Last poll",type=string,JSONPath=".status.lastPollTime",description="Last poll time"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// PaymentsConnector declares a connector installed on the payments module of a stack.
+//
+// The connector is installed, updated and uninstalled through the payments v3 API.
+// The health of the connector is reported through the `ConnectorHealthy` condition.
+type PaymentsConnector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PaymentsConnectorSpec   `json:"spec,omitempty"`
+	Status PaymentsConnectorStatus `json:"status,omitempty"`
+}
+
+func (in *PaymentsConnector) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *PaymentsConnector) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *PaymentsConnector) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *PaymentsConnector) GetStack() string {
+	return in.Spec.Stack
+}
+
+func (in *PaymentsConnector) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+func (in *PaymentsConnector) GetConnectorName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+	return in.Name
+}
+
+//+kubebuilder:object:root=true
+
+// PaymentsConnectorList contains a list of PaymentsConnector
+type PaymentsConnectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PaymentsConnector `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PaymentsConnector{}, &PaymentsConnectorList{})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReconciliationPolicySpec struct {
+	StackDependency `json:",inline"`
+	//+optional
+	// Name of the policy on the reconciliation API, if not provided, the name of the resource is used
+	Name string `json:"name,omitempty"`
+	// LedgerName is the name of the ledger holding the accounts to reconcile
+	LedgerName string `json:"ledgerName"`
+	//+optional
+	// LedgerQuery is the query selecting the accounts of the ledger to reconcile, using the ledger query syntax
+	// (ex: {"$match": {"address": "users:"}})
+	LedgerQuery *apiextensionsv1.JSON `json:"ledgerQuery,omitempty"`
+	// PaymentsPoolID is the id of the payments pool compared to the ledger accounts
+	PaymentsPoolID string `json:"paymentsPoolID"`
+	//+optional
+	// Schedule is a cron expression (ex: "0 * * * *") defining when the reconciliation is run.
+	// If not defined, the policy is created but no reconciliation is run by the operator.
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type ReconciliationResult struct {
+	// ID is the id of the reconciliation on the reconciliation API
+	ID string `json:"id"`
+	// Status of the reconciliation (OK, NOT_OK)
+	Status string `json:"status"`
+	// ReconciledAt is the time at which the balances have been compared
+	ReconciledAt metav1.Time `json:"reconciledAt"`
+	//+optional
+	// DriftBalances contains the difference between the ledger and the payments balances, by asset
+	DriftBalances map[string]string `json:"driftBalances,omitempty"`
+	//+optional
+	// Error is the error reported by the reconciliation, if any
+	Error string `json:"error,omitempty"`
+}
+
+type ReconciliationPolicyStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// PolicyID is the id of the policy on the reconciliation API
+	PolicyID string `json:"policyID,omitempty"`
+	//+optional
+	// ConfigHash is the hash of the configuration last pushed to the reconciliation API
+	ConfigHash string `json:"configHash,omitempty"`
+	//+optional
+	// PendingDeletion are the ids of the replaced policies which remain to be deleted on the reconciliation API
+	PendingDeletion []string `json:"pendingDeletion,omitempty"`
+	//+optional
+	// NextRun is the next time the reconciliation will be run, according to the schedule
+	NextRun *metav1.Time `json:"nextRun,omitempty"`
+	//+optional
+	// LastResult is the result of the last reconciliation run by the operator
+	LastResult *ReconciliationResult `json:"lastResult,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Ledger",type=string,JSONPath=".spec.ledgerName",description="Ledger"
+// +kubebuilder:printcolumn:name="Pool",type=string,JSONPath=".spec.paymentsPoolID",description="Payments pool"
+// +kubebuilder:printcolumn:name="Last result",type=string,JSONPath=".status.lastResult.status",description="Status of the last reconciliation"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// ReconciliationPolicy declares a policy on the reconciliation module of a stack, comparing the balances
+// of ledger accounts to the balances of a payments pool.
+//
+// Policies are immutable on the reconciliation API, so each change of the spec creates a new policy, and the previous
+// one is deleted.
+// When a schedule is defined, the operator runs the reconciliation and reports the latest result in the status.
+type ReconciliationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReconciliationPolicySpec   `json:"spec,omitempty"`
+	Status ReconciliationPolicyStatus `json:"status,omitempty"`
+}
+
+func (in *ReconciliationPolicy) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *ReconciliationPolicy) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *ReconciliationPolicy) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *ReconciliationPolicy) GetStack() string {
+	return in.Spec.Stack
+}
+
+func (in *ReconciliationPolicy) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+func (in *ReconciliationPolicy) GetPolicyName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+	return in.Name
+}
+
+//+kubebuilder:object:root=true
+
+// ReconciliationPolicyList contains a list of ReconciliationPolicy
+type ReconciliationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReconciliationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReconciliationPolicy{}, &ReconciliationPolicyList{})
+}
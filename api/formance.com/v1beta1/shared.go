@@ -172,6 +172,19 @@ func (c *Conditions) AppendOrReplace(newCondition Condition, p ConditionPredicat
 	return &newCondition
 }
 
+// Set adds or replaces the condition of the same type.
+// Like meta.SetStatusCondition, the transition time is kept when the status does not change.
+func (c *Conditions) Set(newCondition Condition) *Condition {
+	if existingCondition := c.Get(newCondition.Type); existingCondition != nil && existingCondition.Status == newCondition.Status {
+		newCondition.LastTransitionTime = existingCondition.LastTransitionTime
+	}
+	if newCondition.LastTransitionTime.IsZero() {
+		newCondition.LastTransitionTime = metav1.Now()
+	}
+
+	return c.AppendOrReplace(newCondition, ConditionTypeMatch(newCondition.Type))
+}
+
 func (c *Conditions) Get(conditionType string) *Condition {
 	for _, condition := range *c {
 		if condition.Type == conditionType {
@@ -222,6 +235,65 @@ func ConditionGenerationMatch(generation int64) ConditionPredicate {
 	}
 }
 
+const (
+	// ConditionTypeReady is true when the object is completely reconciled
+	ConditionTypeReady = "Ready"
+	// ConditionTypeProgressing is true while the object waits for other objects or conditions
+	ConditionTypeProgressing = "Progressing"
+	// ConditionTypeDegraded is true when the last reconciliation of the object terminated with an error
+	ConditionTypeDegraded = "Degraded"
+	// ConditionTypeDriftDetected is true when objects managed for the object have been changed outside of the operator
+	ConditionTypeDriftDetected = "DriftDetected"
+	// ConditionTypeOIDCReachable reports if the discovery document of the delegated oidc server of an Auth is valid
+	ConditionTypeOIDCReachable = "OIDCReachable"
+)
+
+// Reasons of the DriftDetected condition
+const (
+	// ReasonDriftReverted indicates the changes have been reverted
+	ReasonDriftReverted = "DriftReverted"
+	// ReasonDriftIgnored indicates the changes have been kept, as the changed objects are annotated with formance.com/ignore-drift
+	ReasonDriftIgnored = "DriftIgnored"
+)
+
+// Reasons of the Ready, Progressing and Degraded conditions
+const (
+	// ReasonReconciled indicates the object is up to date
+	ReasonReconciled = "Reconciled"
+	// ReasonPending indicates the object waits for another object
+	ReasonPending = "Pending"
+	// ReasonWaitingForCondition indicates a condition of the object is not met yet
+	ReasonWaitingForCondition = "WaitingForCondition"
+	// ReasonStackNotFound indicates the stack of the object does not exist, or is being deleted
+	ReasonStackNotFound = "StackNotFound"
+	// ReasonMissingSettings indicates a required setting is not defined
+	ReasonMissingSettings = "MissingSettings"
+	// ReasonReconcileError indicates the reconciliation failed because of the configuration of the object
+	ReasonReconcileError = "ReconcileError"
+	// ReasonInternalError indicates the reconciliation failed because of an unexpected error, it is retried
+	ReasonInternalError = "InternalError"
+)
+
+// IsStandardCondition returns whether the condition is one of the Ready, Progressing or Degraded conditions
+func IsStandardCondition(condition Condition) bool {
+	switch condition.Type {
+	case ConditionTypeReady, ConditionTypeProgressing, ConditionTypeDegraded:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsInformationalCondition returns whether the condition only reports a state, and does not prevent the object to be ready
+func IsInformationalCondition(condition Condition) bool {
+	switch condition.Type {
+	case ConditionTypeDriftDetected, ConditionTypeOIDCReachable:
+		return true
+	default:
+		return false
+	}
+}
+
 type Status struct {
 	//+optional
 	// Ready indicates if the resource is seen as completely reconciled
@@ -230,6 +302,8 @@ type Status struct {
 	// Info can contain any additional like reconciliation errors
 	Info string `json:"info,omitempty"`
 	//+optional
+	// Conditions contains the standard `Ready`, `Progressing` and `Degraded` conditions,
+	// along with conditions specific to the type of the resource
 	Conditions Conditions `json:"conditions,omitempty"`
 }
 
@@ -391,5 +465,8 @@ func init() {
 const (
 	StackLabel          = "formance.com/stack"
 	SkipLabel           = "formance.com/skip"
+	ShardLabel          = "formance.com/shard"
 	CreatedByAgentLabel = "formance.com/created-by-agent"
+	// IgnoreDriftAnnotation disables the revert of the manual changes made to an object managed by the operator
+	IgnoreDriftAnnotation = "formance.com/ignore-drift"
 )
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,18 +44,87 @@ type StackSpec struct {
 	// A disabled stack disable everything
 	// It just keeps the namespace and the [Database](#database) resources.
 	Disabled bool `json:"disabled"`
+	// +optional
+	// +kubebuilder:default:=false
+	// Hibernated indicate the stack is hibernated.
+	// Unlike a disabled stack, the modules and their resources (streams, consumers, ...) are kept,
+	// only the deployments are scaled to zero.
+	// Waking up the stack restores the deployments without a full reconciliation of the modules.
+	Hibernated bool `json:"hibernated,omitempty"`
+}
+
+type StackHibernationStatus struct {
+	//+optional
+	// NextHibernation is the next time the stack will be hibernated, according to the setting `hibernation.schedule.hibernate`
+	NextHibernation *metav1.Time `json:"nextHibernation,omitempty"`
+	//+optional
+	// NextWakeUp is the next time the stack will be woken up, according to the setting `hibernation.schedule.wake`
+	NextWakeUp *metav1.Time `json:"nextWakeUp,omitempty"`
+}
+
+type DeploymentSummary struct {
+	// Name of the deployment
+	Name string `json:"name"`
+	// Replicas is the number of desired replicas
+	Replicas int32 `json:"replicas"`
+	// AvailableReplicas is the number of available replicas
+	AvailableReplicas int32 `json:"availableReplicas"`
+}
+
+type DependencySummary struct {
+	// Ready indicates if the dependency is ready
+	Ready bool `json:"ready"`
+	//+optional
+	// Error is the last error reported by the dependency
+	Error string `json:"error,omitempty"`
+}
+
+type ModuleSummary struct {
+	// Kind of the module
+	Kind string `json:"kind"`
+	// Name of the module object
+	Name string `json:"name"`
+	//+optional
+	// Version is the version of the module resolved from the module, the stack or the Versions object of the stack.
+	// Empty for modules not released with the stack, like custom modules.
+	Version string `json:"version,omitempty"`
+	// Ready indicates if the module is ready
+	Ready bool `json:"ready"`
+	//+optional
+	// Error is the last error reported by the module
+	Error string `json:"error,omitempty"`
+	//+optional
+	// Deployments are the deployments of the module
+	Deployments []DeploymentSummary `json:"deployments,omitempty"`
+	//+optional
+	// Database reports the readiness of the database of the module, if any
+	Database *DependencySummary `json:"database,omitempty"`
+	//+optional
+	// Broker reports the readiness of the broker used by the module, if any
+	Broker *DependencySummary `json:"broker,omitempty"`
 }
 
 type StackStatus struct {
 	Status `json:",inline"`
 	// Modules register detected modules
 	Modules []string `json:"modules,omitempty"`
+	//+optional
+	// Summary reports the health of each module installed on the stack
+	Summary []ModuleSummary `json:"summary,omitempty"`
+	//+optional
+	// Hibernation reports the schedule of the hibernation of the stack, if configured
+	Hibernation *StackHibernationStatus `json:"hibernation,omitempty"`
+	//+optional
+	// ResourceQuota reports the usage of the resource quota of the stack, if configured with the settings `namespace.resource-quota`
+	ResourceQuota *corev1.ResourceQuotaStatus `json:"resourceQuota,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Disable",type=string,JSONPath=".spec.disabled",description="Stack Disabled"
+//+kubebuilder:printcolumn:name="Hibernated",type=string,JSONPath=".spec.hibernated",description="Stack Hibernated"
 //+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="Stack Version"
 //+kubebuilder:printcolumn:name="Versions From file",type="string",JSONPath=".spec.versionsFromFile",description="Stack Version From File"
 //+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready",description="Is stack ready"
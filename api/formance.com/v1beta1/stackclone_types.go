@@ -0,0 +1,106 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.source != self.destination",message="source and destination must be different"
+type StackCloneSpec struct {
+	// Source is the name of the stack to clone
+	Source string `json:"source"`
+	// Destination is the name of the stack to create
+	Destination string `json:"destination"`
+	//+optional
+	// CopyDatabases allow to copy the content of the databases of the source stack in the databases of the destination stack
+	CopyDatabases bool `json:"copyDatabases,omitempty"`
+	//+optional
+	// RegenerateAuthSecrets allow to generate new secrets for the AuthClient objects, and a new signing key for the Auth module,
+	// instead of reusing the ones of the source stack
+	RegenerateAuthSecrets bool `json:"regenerateAuthSecrets,omitempty"`
+}
+
+type StackCloneStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// ClonedResources lists the resources created for the destination stack, as <kind>/<name>
+	ClonedResources []string `json:"clonedResources,omitempty"`
+	//+optional
+	// CompletedAt is the time at which the clone has been completed
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.spec == oldSelf.spec",message="spec is immutable"
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=".spec.source",description="Source stack"
+// +kubebuilder:printcolumn:name="Destination",type=string,JSONPath=".spec.destination",description="Destination stack"
+// +kubebuilder:printcolumn:name="Completed at",type=string,JSONPath=".status.completedAt",description="Completion time"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// StackClone creates a new stack identical to an existing one.
+//
+// The destination stack is created with the spec of the source stack, then:
+//   - the [Settings](#settings) explicitly targeting the source stack are duplicated for the destination stack
+//   - the modules and the resources of the source stack (AuthClient, Webhook endpoints, ...) are duplicated for the destination stack
+//   - optionally, the databases are copied (see [Database](#database))
+//
+// Objects created for the destination stack are named by replacing the name of the source stack
+// by the name of the destination stack when the name of the object starts with it, or by prefixing the name
+// of the object with the name of the destination stack otherwise.
+//
+// The clone is a one shot operation: once completed, the destination stack is not synchronized anymore with the source stack,
+// and the StackClone object can be safely deleted.
+type StackClone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StackCloneSpec   `json:"spec,omitempty"`
+	Status StackCloneStatus `json:"status,omitempty"`
+}
+
+func (in *StackClone) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *StackClone) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *StackClone) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *StackClone) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// StackCloneList contains a list of StackClone
+type StackCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StackClone `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StackClone{}, &StackCloneList{})
+}
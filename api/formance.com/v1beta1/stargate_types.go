@@ -17,13 +17,20 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type StargateAuthSpec struct {
-	ClientID     string `json:"clientID"`
-	ClientSecret string `json:"clientSecret"`
-	Issuer       string `json:"issuer"`
+	ClientID string `json:"clientID"`
+	//+optional
+	// ClientSecret is the client secret in clear text.
+	// deprecated, use ClientSecretFromSecret
+	ClientSecret string `json:"clientSecret,omitempty"`
+	//+optional
+	// ClientSecretFromSecret references the secret containing the client secret
+	ClientSecretFromSecret *v1.SecretKeySelector `json:"clientSecretFromSecret,omitempty"`
+	Issuer                 string                `json:"issuer"`
 }
 
 type StargateTLSConfig struct {
@@ -52,6 +59,7 @@ type StargateStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
 // +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
@@ -0,0 +1,102 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type WalletSpec struct {
+	StackDependency `json:",inline"`
+	//+optional
+	// Name of the wallet on the wallets API, if not provided, the name of the resource is used.
+	// The name of a wallet cannot be changed once created.
+	Name string `json:"name,omitempty"`
+	//+optional
+	// Metadata of the wallet
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type WalletStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// WalletID is the id of the wallet on the wallets API
+	WalletID string `json:"walletID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,path=stackwallets,singular=wallet
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Wallet ID",type=string,JSONPath=".status.walletID",description="Wallet ID"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// Wallet declares a wallet created on the wallets module of a stack.
+//
+// The wallet is created through the wallets API, and retrieved using the metadata `formance.com/wallet`, set to the
+// name of the resource, so it is never created twice.
+// As the wallets API does not allow to delete a wallet, the wallet is kept when the resource is deleted.
+//
+// The plural name of the resource is `stackwallets`, as `wallets` is used by the Wallets module.
+type Wallet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WalletSpec   `json:"spec,omitempty"`
+	Status WalletStatus `json:"status,omitempty"`
+}
+
+func (in *Wallet) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *Wallet) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *Wallet) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *Wallet) GetStack() string {
+	return in.Spec.Stack
+}
+
+func (in *Wallet) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+func (in *Wallet) GetWalletName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+	return in.Name
+}
+
+//+kubebuilder:object:root=true
+
+// WalletList contains a list of Wallet
+type WalletList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Wallet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Wallet{}, &WalletList{})
+}
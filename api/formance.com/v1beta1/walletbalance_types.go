@@ -0,0 +1,104 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type WalletBalanceSpec struct {
+	StackDependency `json:",inline"`
+	// Wallet is the name of the Wallet resource holding the balance
+	Wallet string `json:"wallet"`
+	//+optional
+	//+kubebuilder:validation:Pattern=`^[0-9A-Za-z_-]+$`
+	// Name of the balance on the wallets API, if not provided, the name of the resource is used
+	Name string `json:"name,omitempty"`
+	//+optional
+	// ExpiresAt is the expiration date of the balance
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	//+optional
+	// Priority of the balance, balances with the highest priority are debited first
+	Priority int `json:"priority,omitempty"`
+}
+
+type WalletBalanceStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// WalletID is the id of the wallet holding the balance on the wallets API
+	WalletID string `json:"walletID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Wallet",type=string,JSONPath=".spec.wallet",description="Wallet"
+// +kubebuilder:printcolumn:name="Wallet ID",type=string,JSONPath=".status.walletID",description="Wallet ID"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// WalletBalance declares a balance of a Wallet.
+//
+// Balances are immutable on the wallets API, the balance is created if missing, and kept when the resource is deleted.
+type WalletBalance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WalletBalanceSpec   `json:"spec,omitempty"`
+	Status WalletBalanceStatus `json:"status,omitempty"`
+}
+
+func (in *WalletBalance) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *WalletBalance) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *WalletBalance) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *WalletBalance) GetStack() string {
+	return in.Spec.Stack
+}
+
+func (in *WalletBalance) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+func (in *WalletBalance) GetBalanceName() string {
+	if in.Spec.Name != "" {
+		return in.Spec.Name
+	}
+	return in.Name
+}
+
+//+kubebuilder:object:root=true
+
+// WalletBalanceList contains a list of WalletBalance
+type WalletBalanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WalletBalance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WalletBalance{}, &WalletBalanceList{})
+}
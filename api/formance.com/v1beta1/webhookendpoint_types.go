@@ -0,0 +1,118 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type WebhookEndpointSpec struct {
+	StackDependency `json:",inline"`
+	// URL is the endpoint receiving the events
+	URL string `json:"url"`
+	//+optional
+	// EventTypes are the types of the events sent to the endpoint (ledger.committed_transactions, payments.saved_payment, ...)
+	// All events are sent if empty
+	EventTypes []string `json:"eventTypes,omitempty"`
+	//+optional
+	// Secret references the secret used to sign the events, it must be a base64 encoded value of 24 bytes.
+	// If not provided, the webhooks module generates one.
+	// The secret must be labeled with formance.com/stack=<stack>|any, like any other secret used by the operator
+	Secret *v1.SecretKeySelector `json:"secret,omitempty"`
+	//+optional
+	//+kubebuilder:default:=false
+	// Paused allow to stop the delivery of the events without removing the endpoint
+	Paused bool `json:"paused,omitempty"`
+}
+
+type WebhookEndpointStatus struct {
+	Status `json:",inline"`
+	//+optional
+	// ConfigID is the id of the config on the webhooks API
+	ConfigID string `json:"configID,omitempty"`
+	//+optional
+	// ConfigHash is the hash of the configuration last pushed to the webhooks API
+	ConfigHash string `json:"configHash,omitempty"`
+	//+optional
+	// PendingDeletion are the ids of the replaced configs which remain to be deleted on the webhooks API
+	PendingDeletion []string `json:"pendingDeletion,omitempty"`
+	//+optional
+	// LastAttempt is the time of the last delivery attempt
+	LastAttempt *metav1.Time `json:"lastAttempt,omitempty"`
+	//+optional
+	// LastAttemptStatus is the status of the last delivery attempt (success, failed, to retry)
+	LastAttemptStatus string `json:"lastAttemptStatus,omitempty"`
+	//+optional
+	// FailureCount is the number of failed attempts since the last successful delivery
+	FailureCount int `json:"failureCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=".spec.url",description="URL"
+// +kubebuilder:printcolumn:name="Last attempt",type=string,JSONPath=".status.lastAttempt",description="Last delivery attempt"
+// +kubebuilder:printcolumn:name="Failures",type=integer,JSONPath=".status.failureCount",description="Failures since last successful delivery"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+
+// WebhookEndpoint declares an endpoint receiving the events of a stack, through the webhooks module.
+//
+// The endpoint is created, updated and deleted through the webhooks API.
+// The status of the deliveries is reported in the status of the resource.
+type WebhookEndpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WebhookEndpointSpec   `json:"spec,omitempty"`
+	Status WebhookEndpointStatus `json:"status,omitempty"`
+}
+
+func (in *WebhookEndpoint) SetReady(b bool) {
+	in.Status.SetReady(b)
+}
+
+func (in *WebhookEndpoint) IsReady() bool {
+	return in.Status.Ready
+}
+
+func (in *WebhookEndpoint) SetError(s string) {
+	in.Status.SetError(s)
+}
+
+func (in *WebhookEndpoint) GetStack() string {
+	return in.Spec.Stack
+}
+
+func (in *WebhookEndpoint) GetConditions() *Conditions {
+	return &in.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// WebhookEndpointList contains a list of WebhookEndpoint
+type WebhookEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WebhookEndpoint `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WebhookEndpoint{}, &WebhookEndpointList{})
+}
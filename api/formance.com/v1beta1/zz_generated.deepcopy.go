@@ -22,6 +22,7 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -112,6 +113,23 @@ func (in *AuthClientList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthClientRotation) DeepCopyInto(out *AuthClientRotation) {
+	*out = *in
+	out.Interval = in.Interval
+	out.Overlap = in.Overlap
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthClientRotation.
+func (in *AuthClientRotation) DeepCopy() *AuthClientRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthClientRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthClientSpec) DeepCopyInto(out *AuthClientSpec) {
 	*out = *in
@@ -136,6 +154,11 @@ func (in *AuthClientSpec) DeepCopyInto(out *AuthClientSpec) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(AuthClientRotation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthClientSpec.
@@ -152,6 +175,14 @@ func (in *AuthClientSpec) DeepCopy() *AuthClientSpec {
 func (in *AuthClientStatus) DeepCopyInto(out *AuthClientStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.LastRotation != nil {
+		in, out := &in.LastRotation, &out.LastRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousSecretExpiresAt != nil {
+		in, out := &in.PreviousSecretExpiresAt, &out.PreviousSecretExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthClientStatus.
@@ -226,6 +257,11 @@ func (in *AuthSpec) DeepCopyInto(out *AuthSpec) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SigningKeyRotation != nil {
+		in, out := &in.SigningKeyRotation, &out.SigningKeyRotation
+		*out = new(SigningKeyRotation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthSpec.
@@ -247,6 +283,18 @@ func (in *AuthStatus) DeepCopyInto(out *AuthStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SigningKey != nil {
+		in, out := &in.SigningKey, &out.SigningKey
+		*out = new(SigningKeyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientSecretHashes != nil {
+		in, out := &in.ClientSecretHashes, &out.ClientSecretHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthStatus.
@@ -801,6 +849,192 @@ func (in Conditions) DeepCopy() Conditions {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomModule) DeepCopyInto(out *CustomModule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomModule.
+func (in *CustomModule) DeepCopy() *CustomModule {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomModule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomModuleAuthClientConfig) DeepCopyInto(out *CustomModuleAuthClientConfig) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomModuleAuthClientConfig.
+func (in *CustomModuleAuthClientConfig) DeepCopy() *CustomModuleAuthClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomModuleAuthClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomModuleBrokerConfig) DeepCopyInto(out *CustomModuleBrokerConfig) {
+	*out = *in
+	if in.Consumes != nil {
+		in, out := &in.Consumes, &out.Consumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomModuleBrokerConfig.
+func (in *CustomModuleBrokerConfig) DeepCopy() *CustomModuleBrokerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomModuleBrokerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomModuleGatewayConfig) DeepCopyInto(out *CustomModuleGatewayConfig) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]GatewayHTTPAPIRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomModuleGatewayConfig.
+func (in *CustomModuleGatewayConfig) DeepCopy() *CustomModuleGatewayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomModuleGatewayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomModuleList) DeepCopyInto(out *CustomModuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CustomModule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomModuleList.
+func (in *CustomModuleList) DeepCopy() *CustomModuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomModuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomModuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomModuleSpec) DeepCopyInto(out *CustomModuleSpec) {
+	*out = *in
+	out.DevProperties = in.DevProperties
+	out.StackDependency = in.StackDependency
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Broker != nil {
+		in, out := &in.Broker, &out.Broker
+		*out = new(CustomModuleBrokerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthClient != nil {
+		in, out := &in.AuthClient, &out.AuthClient
+		*out = new(CustomModuleAuthClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(CustomModuleGatewayConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomModuleSpec.
+func (in *CustomModuleSpec) DeepCopy() *CustomModuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomModuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomModuleStatus) DeepCopyInto(out *CustomModuleStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomModuleStatus.
+func (in *CustomModuleStatus) DeepCopy() *CustomModuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomModuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Database) DeepCopyInto(out *Database) {
 	*out = *in
@@ -899,6 +1133,16 @@ func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DelegatedOIDCServerConfiguration) DeepCopyInto(out *DelegatedOIDCServerConfiguration) {
 	*out = *in
+	if in.Preset != nil {
+		in, out := &in.Preset, &out.Preset
+		*out = new(OIDCProviderPreset)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ClientSecretFromSecret != nil {
 		in, out := &in.ClientSecretFromSecret, &out.ClientSecretFromSecret
 		*out = new(v1.SecretKeySelector)
@@ -916,6 +1160,36 @@ func (in *DelegatedOIDCServerConfiguration) DeepCopy() *DelegatedOIDCServerConfi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencySummary) DeepCopyInto(out *DependencySummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencySummary.
+func (in *DependencySummary) DeepCopy() *DependencySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentSummary) DeepCopyInto(out *DeploymentSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSummary.
+func (in *DeploymentSummary) DeepCopy() *DeploymentSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DevProperties) DeepCopyInto(out *DevProperties) {
 	*out = *in
@@ -985,6 +1259,26 @@ func (in *GatewayHTTPAPI) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayHTTPAPIClaimMatcher) DeepCopyInto(out *GatewayHTTPAPIClaimMatcher) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayHTTPAPIClaimMatcher.
+func (in *GatewayHTTPAPIClaimMatcher) DeepCopy() *GatewayHTTPAPIClaimMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayHTTPAPIClaimMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayHTTPAPIList) DeepCopyInto(out *GatewayHTTPAPIList) {
 	*out = *in
@@ -1025,6 +1319,18 @@ func (in *GatewayHTTPAPIRule) DeepCopyInto(out *GatewayHTTPAPIRule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]GatewayHTTPAPIClaimMatcher, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayHTTPAPIRule.
@@ -1316,6 +1622,51 @@ func (in *ModuleProperties) DeepCopy() *ModuleProperties {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleSummary) DeepCopyInto(out *ModuleSummary) {
+	*out = *in
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]DeploymentSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(DependencySummary)
+		**out = **in
+	}
+	if in.Broker != nil {
+		in, out := &in.Broker, &out.Broker
+		*out = new(DependencySummary)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleSummary.
+func (in *ModuleSummary) DeepCopy() *ModuleSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCProviderPreset) DeepCopyInto(out *OIDCProviderPreset) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProviderPreset.
+func (in *OIDCProviderPreset) DeepCopy() *OIDCProviderPreset {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCProviderPreset)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Orchestration) DeepCopyInto(out *Orchestration) {
 	*out = *in
@@ -1418,7 +1769,7 @@ func (in *OrchestrationStatus) DeepCopy() *OrchestrationStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Payments) DeepCopyInto(out *Payments) {
+func (in *OrchestrationTrigger) DeepCopyInto(out *OrchestrationTrigger) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1426,18 +1777,18 @@ func (in *Payments) DeepCopyInto(out *Payments) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Payments.
-func (in *Payments) DeepCopy() *Payments {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationTrigger.
+func (in *OrchestrationTrigger) DeepCopy() *OrchestrationTrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(Payments)
+	out := new(OrchestrationTrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Payments) DeepCopyObject() runtime.Object {
+func (in *OrchestrationTrigger) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1445,31 +1796,31 @@ func (in *Payments) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PaymentsList) DeepCopyInto(out *PaymentsList) {
+func (in *OrchestrationTriggerList) DeepCopyInto(out *OrchestrationTriggerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Payments, len(*in))
+		*out = make([]OrchestrationTrigger, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PaymentsList.
-func (in *PaymentsList) DeepCopy() *PaymentsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationTriggerList.
+func (in *OrchestrationTriggerList) DeepCopy() *OrchestrationTriggerList {
 	if in == nil {
 		return nil
 	}
-	out := new(PaymentsList)
+	out := new(OrchestrationTriggerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PaymentsList) DeepCopyObject() runtime.Object {
+func (in *OrchestrationTriggerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1477,10 +1828,318 @@ func (in *PaymentsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PaymentsSpec) DeepCopyInto(out *PaymentsSpec) {
+func (in *OrchestrationTriggerSpec) DeepCopyInto(out *OrchestrationTriggerSpec) {
 	*out = *in
 	out.StackDependency = in.StackDependency
-	out.ModuleProperties = in.ModuleProperties
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationTriggerSpec.
+func (in *OrchestrationTriggerSpec) DeepCopy() *OrchestrationTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrchestrationTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrchestrationTriggerStatus) DeepCopyInto(out *OrchestrationTriggerStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.PendingDeletion != nil {
+		in, out := &in.PendingDeletion, &out.PendingDeletion
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationTriggerStatus.
+func (in *OrchestrationTriggerStatus) DeepCopy() *OrchestrationTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrchestrationTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrchestrationWorkflow) DeepCopyInto(out *OrchestrationWorkflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationWorkflow.
+func (in *OrchestrationWorkflow) DeepCopy() *OrchestrationWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(OrchestrationWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrchestrationWorkflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrchestrationWorkflowList) DeepCopyInto(out *OrchestrationWorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrchestrationWorkflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationWorkflowList.
+func (in *OrchestrationWorkflowList) DeepCopy() *OrchestrationWorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrchestrationWorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrchestrationWorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrchestrationWorkflowSpec) DeepCopyInto(out *OrchestrationWorkflowSpec) {
+	*out = *in
+	out.StackDependency = in.StackDependency
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationWorkflowSpec.
+func (in *OrchestrationWorkflowSpec) DeepCopy() *OrchestrationWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrchestrationWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrchestrationWorkflowStatus) DeepCopyInto(out *OrchestrationWorkflowStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.PendingDeletion != nil {
+		in, out := &in.PendingDeletion, &out.PendingDeletion
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrchestrationWorkflowStatus.
+func (in *OrchestrationWorkflowStatus) DeepCopy() *OrchestrationWorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrchestrationWorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Payments) DeepCopyInto(out *Payments) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Payments.
+func (in *Payments) DeepCopy() *Payments {
+	if in == nil {
+		return nil
+	}
+	out := new(Payments)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Payments) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PaymentsConnector) DeepCopyInto(out *PaymentsConnector) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PaymentsConnector.
+func (in *PaymentsConnector) DeepCopy() *PaymentsConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(PaymentsConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PaymentsConnector) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PaymentsConnectorList) DeepCopyInto(out *PaymentsConnectorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PaymentsConnector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PaymentsConnectorList.
+func (in *PaymentsConnectorList) DeepCopy() *PaymentsConnectorList {
+	if in == nil {
+		return nil
+	}
+	out := new(PaymentsConnectorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PaymentsConnectorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PaymentsConnectorSpec) DeepCopyInto(out *PaymentsConnectorSpec) {
+	*out = *in
+	out.StackDependency = in.StackDependency
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make(map[string]v1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PaymentsConnectorSpec.
+func (in *PaymentsConnectorSpec) DeepCopy() *PaymentsConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PaymentsConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PaymentsConnectorStatus) DeepCopyInto(out *PaymentsConnectorStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PaymentsConnectorStatus.
+func (in *PaymentsConnectorStatus) DeepCopy() *PaymentsConnectorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PaymentsConnectorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PaymentsList) DeepCopyInto(out *PaymentsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Payments, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PaymentsList.
+func (in *PaymentsList) DeepCopy() *PaymentsList {
+	if in == nil {
+		return nil
+	}
+	out := new(PaymentsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PaymentsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PaymentsSpec) DeepCopyInto(out *PaymentsSpec) {
+	*out = *in
+	out.StackDependency = in.StackDependency
+	out.ModuleProperties = in.ModuleProperties
 	if in.Auth != nil {
 		in, out := &in.Auth, &out.Auth
 		*out = new(AuthConfig)
@@ -1573,6 +2232,139 @@ func (in *ReconciliationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconciliationPolicy) DeepCopyInto(out *ReconciliationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationPolicy.
+func (in *ReconciliationPolicy) DeepCopy() *ReconciliationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconciliationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReconciliationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconciliationPolicyList) DeepCopyInto(out *ReconciliationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReconciliationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationPolicyList.
+func (in *ReconciliationPolicyList) DeepCopy() *ReconciliationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconciliationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReconciliationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconciliationPolicySpec) DeepCopyInto(out *ReconciliationPolicySpec) {
+	*out = *in
+	out.StackDependency = in.StackDependency
+	if in.LedgerQuery != nil {
+		in, out := &in.LedgerQuery, &out.LedgerQuery
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationPolicySpec.
+func (in *ReconciliationPolicySpec) DeepCopy() *ReconciliationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconciliationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconciliationPolicyStatus) DeepCopyInto(out *ReconciliationPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.PendingDeletion != nil {
+		in, out := &in.PendingDeletion, &out.PendingDeletion
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextRun != nil {
+		in, out := &in.NextRun, &out.NextRun
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResult != nil {
+		in, out := &in.LastResult, &out.LastResult
+		*out = new(ReconciliationResult)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationPolicyStatus.
+func (in *ReconciliationPolicyStatus) DeepCopy() *ReconciliationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconciliationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconciliationResult) DeepCopyInto(out *ReconciliationResult) {
+	*out = *in
+	in.ReconciledAt.DeepCopyInto(&out.ReconciledAt)
+	if in.DriftBalances != nil {
+		in, out := &in.DriftBalances, &out.DriftBalances
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationResult.
+func (in *ReconciliationResult) DeepCopy() *ReconciliationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconciliationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReconciliationSpec) DeepCopyInto(out *ReconciliationSpec) {
 	*out = *in
@@ -1886,6 +2678,46 @@ func (in *SettingsSpec) DeepCopy() *SettingsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigningKeyRotation) DeepCopyInto(out *SigningKeyRotation) {
+	*out = *in
+	out.Interval = in.Interval
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigningKeyRotation.
+func (in *SigningKeyRotation) DeepCopy() *SigningKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(SigningKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigningKeyStatus) DeepCopyInto(out *SigningKeyStatus) {
+	*out = *in
+	if in.LastRotation != nil {
+		in, out := &in.LastRotation, &out.LastRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousKeyExpiresAt != nil {
+		in, out := &in.PreviousKeyExpiresAt, &out.PreviousKeyExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigningKeyStatus.
+func (in *SigningKeyStatus) DeepCopy() *SigningKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SigningKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stack) DeepCopyInto(out *Stack) {
 	*out = *in
@@ -1913,17 +2745,139 @@ func (in *Stack) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackClone) DeepCopyInto(out *StackClone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackClone.
+func (in *StackClone) DeepCopy() *StackClone {
+	if in == nil {
+		return nil
+	}
+	out := new(StackClone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StackClone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackCloneList) DeepCopyInto(out *StackCloneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StackClone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackCloneList.
+func (in *StackCloneList) DeepCopy() *StackCloneList {
+	if in == nil {
+		return nil
+	}
+	out := new(StackCloneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StackCloneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackCloneSpec) DeepCopyInto(out *StackCloneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackCloneSpec.
+func (in *StackCloneSpec) DeepCopy() *StackCloneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StackCloneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackCloneStatus) DeepCopyInto(out *StackCloneStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.ClonedResources != nil {
+		in, out := &in.ClonedResources, &out.ClonedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackCloneStatus.
+func (in *StackCloneStatus) DeepCopy() *StackCloneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StackCloneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StackDependency) DeepCopyInto(out *StackDependency) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackDependency.
-func (in *StackDependency) DeepCopy() *StackDependency {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackDependency.
+func (in *StackDependency) DeepCopy() *StackDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(StackDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackHibernationStatus) DeepCopyInto(out *StackHibernationStatus) {
+	*out = *in
+	if in.NextHibernation != nil {
+		in, out := &in.NextHibernation, &out.NextHibernation
+		*out = (*in).DeepCopy()
+	}
+	if in.NextWakeUp != nil {
+		in, out := &in.NextWakeUp, &out.NextWakeUp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackHibernationStatus.
+func (in *StackHibernationStatus) DeepCopy() *StackHibernationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(StackDependency)
+	out := new(StackHibernationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1985,6 +2939,23 @@ func (in *StackStatus) DeepCopyInto(out *StackStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = make([]ModuleSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(StackHibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(v1.ResourceQuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackStatus.
@@ -2002,7 +2973,7 @@ func (in *Stargate) DeepCopyInto(out *Stargate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -2027,6 +2998,11 @@ func (in *Stargate) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StargateAuthSpec) DeepCopyInto(out *StargateAuthSpec) {
 	*out = *in
+	if in.ClientSecretFromSecret != nil {
+		in, out := &in.ClientSecretFromSecret, &out.ClientSecretFromSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StargateAuthSpec.
@@ -2076,7 +3052,7 @@ func (in *StargateSpec) DeepCopyInto(out *StargateSpec) {
 	*out = *in
 	out.ModuleProperties = in.ModuleProperties
 	out.StackDependency = in.StackDependency
-	out.Auth = in.Auth
+	in.Auth.DeepCopyInto(&out.Auth)
 	out.TLS = in.TLS
 }
 
@@ -2314,6 +3290,199 @@ func (in *VersionsList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Wallet) DeepCopyInto(out *Wallet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Wallet.
+func (in *Wallet) DeepCopy() *Wallet {
+	if in == nil {
+		return nil
+	}
+	out := new(Wallet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Wallet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletBalance) DeepCopyInto(out *WalletBalance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletBalance.
+func (in *WalletBalance) DeepCopy() *WalletBalance {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletBalance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WalletBalance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletBalanceList) DeepCopyInto(out *WalletBalanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WalletBalance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletBalanceList.
+func (in *WalletBalanceList) DeepCopy() *WalletBalanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletBalanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WalletBalanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletBalanceSpec) DeepCopyInto(out *WalletBalanceSpec) {
+	*out = *in
+	out.StackDependency = in.StackDependency
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletBalanceSpec.
+func (in *WalletBalanceSpec) DeepCopy() *WalletBalanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletBalanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletBalanceStatus) DeepCopyInto(out *WalletBalanceStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletBalanceStatus.
+func (in *WalletBalanceStatus) DeepCopy() *WalletBalanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletBalanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletList) DeepCopyInto(out *WalletList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Wallet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletList.
+func (in *WalletList) DeepCopy() *WalletList {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WalletList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletSpec) DeepCopyInto(out *WalletSpec) {
+	*out = *in
+	out.StackDependency = in.StackDependency
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletSpec.
+func (in *WalletSpec) DeepCopy() *WalletSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletStatus) DeepCopyInto(out *WalletStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletStatus.
+func (in *WalletStatus) DeepCopy() *WalletStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Wallets) DeepCopyInto(out *Wallets) {
 	*out = *in
@@ -2411,6 +3580,116 @@ func (in *WalletsStatus) DeepCopy() *WalletsStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookEndpoint) DeepCopyInto(out *WebhookEndpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookEndpoint.
+func (in *WebhookEndpoint) DeepCopy() *WebhookEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookEndpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookEndpointList) DeepCopyInto(out *WebhookEndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WebhookEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookEndpointList.
+func (in *WebhookEndpointList) DeepCopy() *WebhookEndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookEndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookEndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookEndpointSpec) DeepCopyInto(out *WebhookEndpointSpec) {
+	*out = *in
+	out.StackDependency = in.StackDependency
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookEndpointSpec.
+func (in *WebhookEndpointSpec) DeepCopy() *WebhookEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookEndpointStatus) DeepCopyInto(out *WebhookEndpointStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.PendingDeletion != nil {
+		in, out := &in.PendingDeletion, &out.PendingDeletion
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAttempt != nil {
+		in, out := &in.LastAttempt, &out.LastAttempt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookEndpointStatus.
+func (in *WebhookEndpointStatus) DeepCopy() *WebhookEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Webhooks) DeepCopyInto(out *Webhooks) {
 	*out = *in
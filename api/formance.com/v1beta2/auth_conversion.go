@@ -0,0 +1,97 @@
+package v1beta2
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+// authV1beta1Fields only indicates which secrets are defined in clear text, their values are not stored
+type authV1beta1Fields struct {
+	SigningKeyInClearText                      bool `json:"signingKeyInClearText,omitempty"`
+	DelegatedOIDCServerClientSecretInClearText bool `json:"delegatedOIDCServerClientSecretInClearText,omitempty"`
+}
+
+// ConvertTo converts this Auth to the hub version (v1beta1)
+func (src *Auth) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Auth)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	fields := authV1beta1Fields{}
+	if err := restoreFields(dst, &fields); err != nil {
+		return err
+	}
+
+	dst.Spec = v1beta1.AuthSpec{
+		ModuleProperties: src.Spec.ModuleProperties,
+		StackDependency:  src.Spec.StackDependency,
+		EnableScopes:     src.Spec.EnableScopes,
+	}
+	// The secrets in clear text can't be restored, references are required
+	if oidc := src.Spec.DelegatedOIDCServer; oidc != nil {
+		dst.Spec.DelegatedOIDCServer = &v1beta1.DelegatedOIDCServerConfiguration{
+			Preset:                 oidc.Preset.DeepCopy(),
+			Issuer:                 oidc.Issuer,
+			Scopes:                 append([]string(nil), oidc.Scopes...),
+			EmailClaim:             oidc.EmailClaim,
+			ClientID:               oidc.ClientID,
+			ClientSecretFromSecret: oidc.ClientSecretFromSecret.DeepCopy(),
+		}
+		if fields.DelegatedOIDCServerClientSecretInClearText && oidc.ClientSecretFromSecret == nil {
+			return errClearTextSecret("spec.delegatedOIDCServer.clientSecret", "spec.delegatedOIDCServer.clientSecretFromSecret")
+		}
+	}
+	if signingKey := src.Spec.SigningKey; signingKey != nil {
+		dst.Spec.SigningKeyFromSecret = signingKey.FromSecret.DeepCopy()
+		dst.Spec.SigningKeyAlgorithm = signingKey.Algorithm
+		dst.Spec.SigningKeyRotation = signingKey.Rotation.DeepCopy()
+	}
+	if fields.SigningKeyInClearText && dst.Spec.SigningKeyFromSecret == nil {
+		return errClearTextSecret("spec.signingKey", "spec.signingKey.fromSecret")
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta1) to this version
+func (dst *Auth) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Auth)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	fields := authV1beta1Fields{
+		SigningKeyInClearText: src.Spec.SigningKey != "",
+	}
+	if src.Spec.DelegatedOIDCServer != nil {
+		fields.DelegatedOIDCServerClientSecretInClearText = src.Spec.DelegatedOIDCServer.ClientSecret != ""
+	}
+	if err := saveFields(dst, fields); err != nil {
+		return err
+	}
+
+	dst.Spec = AuthSpec{
+		ModuleProperties: src.Spec.ModuleProperties,
+		StackDependency:  src.Spec.StackDependency,
+		EnableScopes:     src.Spec.EnableScopes,
+	}
+	if oidc := src.Spec.DelegatedOIDCServer; oidc != nil {
+		dst.Spec.DelegatedOIDCServer = &DelegatedOIDCServerConfiguration{
+			Preset:                 oidc.Preset.DeepCopy(),
+			Issuer:                 oidc.Issuer,
+			Scopes:                 append([]string(nil), oidc.Scopes...),
+			EmailClaim:             oidc.EmailClaim,
+			ClientID:               oidc.ClientID,
+			ClientSecretFromSecret: oidc.ClientSecretFromSecret.DeepCopy(),
+		}
+	}
+	if src.Spec.SigningKeyFromSecret != nil || src.Spec.SigningKeyAlgorithm != "" || src.Spec.SigningKeyRotation != nil {
+		dst.Spec.SigningKey = &SigningKeySpec{
+			FromSecret: src.Spec.SigningKeyFromSecret.DeepCopy(),
+			Algorithm:  src.Spec.SigningKeyAlgorithm,
+			Rotation:   src.Spec.SigningKeyRotation.DeepCopy(),
+		}
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+
+	return nil
+}
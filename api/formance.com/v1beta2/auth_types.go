@@ -0,0 +1,114 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+type DelegatedOIDCServerConfiguration struct {
+	//+optional
+	// Preset allow to use the defaults of a well known provider.
+	// The issuer, scopes and email claim are deduced from the preset when not defined.
+	Preset *v1beta1.OIDCProviderPreset `json:"preset,omitempty"`
+	//+optional
+	// Issuer is the url of the delegated oidc server
+	Issuer string `json:"issuer,omitempty"`
+	//+optional
+	// Scopes are the scopes requested to the delegated oidc server
+	Scopes []string `json:"scopes,omitempty"`
+	//+optional
+	// EmailClaim is the claim of the id token containing the email of the user
+	EmailClaim string `json:"emailClaim,omitempty"`
+	// ClientID is the client id to use for authentication
+	ClientID string `json:"clientID,omitempty"`
+	//+optional
+	// ClientSecretFromSecret references the secret containing the client secret to use for authentication
+	ClientSecretFromSecret *v1.SecretKeySelector `json:"clientSecretFromSecret,omitempty"`
+}
+
+type SigningKeySpec struct {
+	//+optional
+	// FromSecret references the secret containing the signing key used to sign JWT tokens.
+	// The operator generates a signing key when not defined.
+	FromSecret *v1.SecretKeySelector `json:"fromSecret,omitempty"`
+	//+optional
+	//+kubebuilder:validation:Enum:={RS256, ES256}
+	// Algorithm is the algorithm of the signing key generated by the operator (RS256 if not defined).
+	Algorithm string `json:"algorithm,omitempty"`
+	//+optional
+	// Rotation allow to periodically rotate the signing key generated by the operator.
+	// It is ignored if a signing key is provided.
+	Rotation *v1beta1.SigningKeyRotation `json:"rotation,omitempty"`
+}
+
+type AuthSpec struct {
+	v1beta1.ModuleProperties `json:",inline"`
+	v1beta1.StackDependency  `json:",inline"`
+	//+optional
+	// Contains information about a delegated authentication server to use to delegate authentication
+	DelegatedOIDCServer *DelegatedOIDCServerConfiguration `json:"delegatedOIDCServer,omitempty"`
+	//+optional
+	// SigningKey configures the key used to sign JWT tokens
+	SigningKey *SigningKeySpec `json:"signingKey,omitempty"`
+	//+optional
+	// Allow to enable scopes usage on authentication.
+	//
+	// If not enabled, each service will check the authentication but will not restrict access following scopes.
+	// in this case, if authenticated, it is ok.
+	// +kubebuilder:default:=false
+	EnableScopes bool `json:"enableScopes"`
+}
+
+// Auth represent the authentication module of a stack.
+//
+// Unlike v1beta1, the secrets can only be provided using references to k8s secrets,
+// and the configuration of the signing key is grouped in the `.spec.signingKey` section.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="Clients",type=string,JSONPath=".status.clients",description="Synchronized auth clients"
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".spec.version",description="Version"
+// +kubebuilder:metadata:labels=formance.com/kind=module
+// +kubebuilder:metadata:labels=formance.com/is-ee=true
+type Auth struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AuthSpec           `json:"spec,omitempty"`
+	Status v1beta1.AuthStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AuthList contains a list of Auth
+type AuthList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Auth `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Auth{}, &AuthList{})
+}
@@ -0,0 +1,58 @@
+package v1beta2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConversionAnnotation keeps the v1beta1 fields without equivalent in v1beta2 (deprecated fields),
+// so an object read and updated using v1beta2 does not lose them.
+// The secrets in clear text are never stored in the annotation, only the fact that they are defined.
+const ConversionAnnotation = "formance.com/v1beta1-fields"
+
+// errClearTextSecret is returned when an object updated using v1beta2 would lose a secret defined in clear text using v1beta1
+func errClearTextSecret(field, reference string) error {
+	return fmt.Errorf("%s is defined in clear text, which is not supported by v1beta2: define %s, or update the object using v1beta1", field, reference)
+}
+
+// saveFields stores the v1beta1 fields lost by the conversion in the annotations of the v1beta2 object
+func saveFields[T comparable](object metav1.Object, fields T) error {
+	annotations := object.GetAnnotations()
+	delete(annotations, ConversionAnnotation)
+
+	var zero T
+	if fields != zero {
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ConversionAnnotation] = string(data)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	object.SetAnnotations(annotations)
+
+	return nil
+}
+
+// restoreFields reads the v1beta1 fields stored by saveFields, and removes the annotation from the v1beta1 object
+func restoreFields[T any](object metav1.Object, fields *T) error {
+	annotations := object.GetAnnotations()
+	data, ok := annotations[ConversionAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(annotations, ConversionAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	object.SetAnnotations(annotations)
+
+	return json.Unmarshal([]byte(data), fields)
+}
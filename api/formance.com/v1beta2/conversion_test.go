@@ -0,0 +1,250 @@
+package v1beta2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func secretKeySelector(name, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: name,
+		},
+		Key: key,
+	}
+}
+
+func TestIsConvertible(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	for _, object := range []runtime.Object{&Stack{}, &Stargate{}, &Auth{}} {
+		ok, err := conversion.IsConvertible(scheme, object)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+}
+
+func TestStackConversion(t *testing.T) {
+	t.Parallel()
+
+	hub := &v1beta1.Stack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "formance-dev",
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+		},
+		Spec: v1beta1.StackSpec{
+			DevProperties: v1beta1.DevProperties{
+				Debug: true,
+			},
+			Version:          "v2.2.0",
+			VersionsFromFile: "default",
+			EnableAudit:      true,
+			Hibernated:       true,
+		},
+		Status: v1beta1.StackStatus{
+			Modules: []string{"Ledger"},
+		},
+	}
+
+	stack := &Stack{}
+	require.NoError(t, stack.ConvertFrom(hub))
+	require.Equal(t, StackVersions{
+		Version:  "v2.2.0",
+		FromFile: "default",
+	}, stack.Spec.Versions)
+	require.True(t, stack.Spec.Debug)
+	require.True(t, stack.Spec.Hibernated)
+	require.Equal(t, hub.Status, stack.Status)
+	require.Contains(t, stack.Annotations, ConversionAnnotation)
+
+	restored := &v1beta1.Stack{}
+	require.NoError(t, stack.ConvertTo(restored))
+	require.Equal(t, hub, restored)
+}
+
+func TestStackConversionWithoutDeprecatedFields(t *testing.T) {
+	t.Parallel()
+
+	hub := &v1beta1.Stack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "formance-dev",
+		},
+		Spec: v1beta1.StackSpec{
+			Version: "v2.2.0",
+		},
+	}
+
+	stack := &Stack{}
+	require.NoError(t, stack.ConvertFrom(hub))
+	require.Nil(t, stack.Annotations)
+
+	restored := &v1beta1.Stack{}
+	require.NoError(t, stack.ConvertTo(restored))
+	require.Equal(t, hub, restored)
+}
+
+func TestStargateConversion(t *testing.T) {
+	t.Parallel()
+
+	hub := &v1beta1.Stargate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "formance-dev",
+		},
+		Spec: v1beta1.StargateSpec{
+			StackDependency: v1beta1.StackDependency{
+				Stack: "formance-dev",
+			},
+			ServerURL:      "server:8080",
+			OrganizationID: "orgID",
+			StackID:        "stackID",
+			Auth: v1beta1.StargateAuthSpec{
+				ClientID:     "client0",
+				ClientSecret: "secret0",
+				Issuer:       "http://server:8081",
+			},
+		},
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		hub := hub.DeepCopy()
+		hub.Spec.Auth.ClientSecret = ""
+		hub.Spec.Auth.ClientSecretFromSecret = secretKeySelector("stargate", "secret")
+
+		stargate := &Stargate{}
+		require.NoError(t, stargate.ConvertFrom(hub))
+		require.Equal(t, "client0", stargate.Spec.Auth.ClientID)
+		require.Nil(t, stargate.Annotations)
+
+		restored := &v1beta1.Stargate{}
+		require.NoError(t, stargate.ConvertTo(restored))
+		require.Equal(t, hub, restored)
+	})
+
+	t.Run("secret in clear text", func(t *testing.T) {
+		t.Parallel()
+
+		stargate := &Stargate{}
+		require.NoError(t, stargate.ConvertFrom(hub))
+		require.Nil(t, stargate.Spec.Auth.ClientSecretFromSecret)
+		require.Contains(t, stargate.Annotations, ConversionAnnotation)
+		require.NotContains(t, stargate.Annotations[ConversionAnnotation], "secret0")
+
+		restored := &v1beta1.Stargate{}
+		require.ErrorContains(t, stargate.ConvertTo(restored), "spec.auth.clientSecretFromSecret")
+	})
+
+	t.Run("reference defined using v1beta2", func(t *testing.T) {
+		t.Parallel()
+
+		stargate := &Stargate{}
+		require.NoError(t, stargate.ConvertFrom(hub))
+		stargate.Spec.Auth.ClientSecretFromSecret = secretKeySelector("stargate", "secret")
+
+		restored := &v1beta1.Stargate{}
+		require.NoError(t, stargate.ConvertTo(restored))
+		require.Empty(t, restored.Spec.Auth.ClientSecret)
+		require.Equal(t, secretKeySelector("stargate", "secret"), restored.Spec.Auth.ClientSecretFromSecret)
+		require.NotContains(t, restored.Annotations, ConversionAnnotation)
+	})
+}
+
+func TestAuthConversion(t *testing.T) {
+	t.Parallel()
+
+	hub := &v1beta1.Auth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "formance-dev",
+		},
+		Spec: v1beta1.AuthSpec{
+			StackDependency: v1beta1.StackDependency{
+				Stack: "formance-dev",
+			},
+			DelegatedOIDCServer: &v1beta1.DelegatedOIDCServerConfiguration{
+				Issuer:       "http://dex:5556",
+				Scopes:       []string{"openid"},
+				ClientID:     "formance",
+				ClientSecret: "secret0",
+			},
+			SigningKey:          "key",
+			SigningKeyAlgorithm: "ES256",
+			SigningKeyRotation: &v1beta1.SigningKeyRotation{
+				Interval: metav1.Duration{Duration: 24 * time.Hour},
+			},
+			EnableScopes: true,
+		},
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		hub := hub.DeepCopy()
+		hub.Spec.SigningKey = ""
+		hub.Spec.SigningKeyFromSecret = secretKeySelector("signing-key", "key")
+		hub.Spec.DelegatedOIDCServer.ClientSecret = ""
+		hub.Spec.DelegatedOIDCServer.ClientSecretFromSecret = secretKeySelector("oidc", "secret")
+
+		auth := &Auth{}
+		require.NoError(t, auth.ConvertFrom(hub))
+		require.Equal(t, &SigningKeySpec{
+			FromSecret: secretKeySelector("signing-key", "key"),
+			Algorithm:  "ES256",
+			Rotation: &v1beta1.SigningKeyRotation{
+				Interval: metav1.Duration{Duration: 24 * time.Hour},
+			},
+		}, auth.Spec.SigningKey)
+		require.Equal(t, "formance", auth.Spec.DelegatedOIDCServer.ClientID)
+		require.Nil(t, auth.Annotations)
+
+		restored := &v1beta1.Auth{}
+		require.NoError(t, auth.ConvertTo(restored))
+		require.Equal(t, hub, restored)
+	})
+
+	t.Run("secrets in clear text", func(t *testing.T) {
+		t.Parallel()
+
+		auth := &Auth{}
+		require.NoError(t, auth.ConvertFrom(hub))
+		require.Contains(t, auth.Annotations, ConversionAnnotation)
+		require.NotContains(t, auth.Annotations[ConversionAnnotation], "secret0")
+		require.NotContains(t, auth.Annotations[ConversionAnnotation], `"key"`)
+
+		restored := &v1beta1.Auth{}
+		require.ErrorContains(t, auth.ConvertTo(restored), "spec.delegatedOIDCServer.clientSecretFromSecret")
+
+		auth.Spec.DelegatedOIDCServer.ClientSecretFromSecret = secretKeySelector("oidc", "secret")
+		require.ErrorContains(t, auth.ConvertTo(restored), "spec.signingKey.fromSecret")
+	})
+
+	t.Run("references defined using v1beta2", func(t *testing.T) {
+		t.Parallel()
+
+		auth := &Auth{}
+		require.NoError(t, auth.ConvertFrom(hub))
+		auth.Spec.DelegatedOIDCServer.ClientSecretFromSecret = secretKeySelector("oidc", "secret")
+		auth.Spec.SigningKey.FromSecret = secretKeySelector("signing-key", "key")
+
+		restored := &v1beta1.Auth{}
+		require.NoError(t, auth.ConvertTo(restored))
+		require.Empty(t, restored.Spec.SigningKey)
+		require.Equal(t, secretKeySelector("signing-key", "key"), restored.Spec.SigningKeyFromSecret)
+		require.Empty(t, restored.Spec.DelegatedOIDCServer.ClientSecret)
+		require.Equal(t, secretKeySelector("oidc", "secret"), restored.Spec.DelegatedOIDCServer.ClientSecretFromSecret)
+	})
+}
@@ -0,0 +1,59 @@
+package v1beta2
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+type stackV1beta1Fields struct {
+	EnableAudit bool `json:"enableAudit,omitempty"`
+}
+
+// ConvertTo converts this Stack to the hub version (v1beta1)
+func (src *Stack) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Stack)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	fields := stackV1beta1Fields{}
+	if err := restoreFields(dst, &fields); err != nil {
+		return err
+	}
+
+	dst.Spec = v1beta1.StackSpec{
+		DevProperties:    src.Spec.DevProperties,
+		Version:          src.Spec.Versions.Version,
+		VersionsFromFile: src.Spec.Versions.FromFile,
+		EnableAudit:      fields.EnableAudit,
+		Disabled:         src.Spec.Disabled,
+		Hibernated:       src.Spec.Hibernated,
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta1) to this version
+func (dst *Stack) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Stack)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := saveFields(dst, stackV1beta1Fields{
+		EnableAudit: src.Spec.EnableAudit,
+	}); err != nil {
+		return err
+	}
+
+	dst.Spec = StackSpec{
+		DevProperties: src.Spec.DevProperties,
+		Versions: StackVersions{
+			Version:  src.Spec.Version,
+			FromFile: src.Spec.VersionsFromFile,
+		},
+		Disabled:   src.Spec.Disabled,
+		Hibernated: src.Spec.Hibernated,
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+
+	return nil
+}
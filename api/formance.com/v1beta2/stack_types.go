@@ -0,0 +1,93 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+type StackVersions struct {
+	// +optional
+	// Version allow to specify the version of the components
+	// Must be a valid docker tag
+	Version string `json:"version,omitempty"`
+	// +optional
+	// FromFile allow to specify a formance.com/Versions object which contains individual versions
+	// for each component.
+	// Must reference a valid formance.com/Versions object
+	FromFile string `json:"fromFile,omitempty"`
+}
+
+type StackSpec struct {
+	v1beta1.DevProperties `json:",inline"`
+	// +optional
+	// Versions defines the versions of the components.
+	// The `version` field will have priority over `fromFile`.
+	Versions StackVersions `json:"versions,omitempty"`
+	// +optional
+	// +kubebuilder:default:=false
+	// Disabled indicate the stack is disabled.
+	// A disabled stack disable everything
+	// It just keeps the namespace and the [Database](#database) resources.
+	Disabled bool `json:"disabled"`
+	// +optional
+	// +kubebuilder:default:=false
+	// Hibernated indicate the stack is hibernated.
+	// Unlike a disabled stack, the modules and their resources (streams, consumers, ...) are kept,
+	// only the deployments are scaled to zero.
+	Hibernated bool `json:"hibernated,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Disable",type=string,JSONPath=".spec.disabled",description="Stack Disabled"
+//+kubebuilder:printcolumn:name="Hibernated",type=string,JSONPath=".spec.hibernated",description="Stack Hibernated"
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.versions.version",description="Stack Version"
+//+kubebuilder:printcolumn:name="Versions From file",type="string",JSONPath=".spec.versions.fromFile",description="Stack Version From File"
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready",description="Is stack ready"
+//+kubebuilder:printcolumn:name="Modules",type=string,JSONPath=".status.modules",description="Modules List Registered"
+//+kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+//+kubebuilder:printcolumn:name="Created",type=date,JSONPath=".metadata.creationTimestamp",description="Creation Timestamp"
+
+// Stack represents a formance stack.
+//
+// Unlike v1beta1, the versions of the components are grouped in the `.spec.versions` section,
+// and the deprecated field `enableAudit` is removed.
+type Stack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StackSpec           `json:"spec,omitempty"`
+	Status v1beta1.StackStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// StackList contains a list of Stack
+type StackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Stack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Stack{}, &StackList{})
+}
@@ -0,0 +1,73 @@
+package v1beta2
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+type stargateV1beta1Fields struct {
+	// ClientSecretInClearText indicates spec.auth.clientSecret is defined, its value is not stored
+	ClientSecretInClearText bool `json:"clientSecretInClearText,omitempty"`
+}
+
+// ConvertTo converts this Stargate to the hub version (v1beta1)
+func (src *Stargate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Stargate)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	fields := stargateV1beta1Fields{}
+	if err := restoreFields(dst, &fields); err != nil {
+		return err
+	}
+
+	dst.Spec = v1beta1.StargateSpec{
+		ModuleProperties: src.Spec.ModuleProperties,
+		StackDependency:  src.Spec.StackDependency,
+		ServerURL:        src.Spec.ServerURL,
+		OrganizationID:   src.Spec.OrganizationID,
+		StackID:          src.Spec.StackID,
+		Auth: v1beta1.StargateAuthSpec{
+			ClientID:               src.Spec.Auth.ClientID,
+			ClientSecretFromSecret: src.Spec.Auth.ClientSecretFromSecret.DeepCopy(),
+			Issuer:                 src.Spec.Auth.Issuer,
+		},
+		TLS: src.Spec.TLS,
+	}
+	// The secret in clear text can't be restored, a reference is required
+	if fields.ClientSecretInClearText && dst.Spec.Auth.ClientSecretFromSecret == nil {
+		return errClearTextSecret("spec.auth.clientSecret", "spec.auth.clientSecretFromSecret")
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta1) to this version
+func (dst *Stargate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Stargate)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := saveFields(dst, stargateV1beta1Fields{
+		ClientSecretInClearText: src.Spec.Auth.ClientSecret != "",
+	}); err != nil {
+		return err
+	}
+
+	dst.Spec = StargateSpec{
+		ModuleProperties: src.Spec.ModuleProperties,
+		StackDependency:  src.Spec.StackDependency,
+		ServerURL:        src.Spec.ServerURL,
+		OrganizationID:   src.Spec.OrganizationID,
+		StackID:          src.Spec.StackID,
+		Auth: StargateAuthSpec{
+			ClientID:               src.Spec.Auth.ClientID,
+			ClientSecretFromSecret: src.Spec.Auth.ClientSecretFromSecret.DeepCopy(),
+			Issuer:                 src.Spec.Auth.Issuer,
+		},
+		TLS: src.Spec.TLS,
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+
+	return nil
+}
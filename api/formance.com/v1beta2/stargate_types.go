@@ -0,0 +1,77 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+type StargateAuthSpec struct {
+	ClientID string `json:"clientID"`
+	//+optional
+	// ClientSecretFromSecret references the secret containing the client secret
+	ClientSecretFromSecret *v1.SecretKeySelector `json:"clientSecretFromSecret,omitempty"`
+	Issuer                 string                `json:"issuer"`
+}
+
+type StargateSpec struct {
+	v1beta1.ModuleProperties `json:",inline"`
+	v1beta1.StackDependency  `json:",inline"`
+	ServerURL                string           `json:"serverURL"`
+	OrganizationID           string           `json:"organizationID"`
+	StackID                  string           `json:"stackID"`
+	Auth                     StargateAuthSpec `json:"auth"`
+
+	//+optional
+	TLS v1beta1.StargateTLSConfig `json:"tls"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="Stack",type=string,JSONPath=".spec.stack",description="Stack"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.ready",description="Is ready"
+// +kubebuilder:printcolumn:name="Info",type=string,JSONPath=".status.info",description="Info"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".spec.version",description="Version"
+// +kubebuilder:metadata:labels=formance.com/kind=module
+// Stargate is the Schema for the stargates API
+//
+// Unlike v1beta1, the client secret can only be provided using a reference to a k8s secret.
+type Stargate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StargateSpec           `json:"spec,omitempty"`
+	Status v1beta1.StargateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// StargateList contains a list of Stargate
+type StargateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Stargate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Stargate{}, &StargateList{})
+}
@@ -20,15 +20,19 @@ import (
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"net/http"
 	"os"
+	"time"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -36,6 +40,7 @@ import (
 	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 
 	formancev1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	formancev1beta2 "github.com/formancehq/operator/v3/api/formance.com/v1beta2"
 	"github.com/formancehq/operator/v3/internal/core"
 	_ "github.com/formancehq/operator/v3/internal/resources"
 )
@@ -48,6 +53,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(formancev1beta1.AddToScheme(scheme))
+	utilruntime.Must(formancev1beta2.AddToScheme(scheme))
 	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(externaldnsv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -58,13 +64,22 @@ func main() {
 		metricsAddr          string
 		enableLeaderElection bool
 		probeAddr            string
+		stackHealthAddr      string
 		region               string
 		env                  string
 		licenceSecret        string
 		utilsVersion         string
+		maxRequeueDelay      time.Duration
+		shards               int
+		shard                int
+		stackSelector        string
+		stackNamePrefix      string
+		disableWebhooks      bool
 	)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&stackHealthAddr, "stack-health-bind-address", "",
+		"The address the unauthenticated health endpoint of the stacks binds to, disabled if empty.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -72,6 +87,14 @@ func main() {
 	flag.StringVar(&env, "env", "staging", "The current environment in use for the operator")
 	flag.StringVar(&licenceSecret, "licence-secret", "", "The licence secret that contains the token and the issuer")
 	flag.StringVar(&utilsVersion, "utils-version", "latest", "The version of the operator utils image")
+	flag.DurationVar(&maxRequeueDelay, "max-requeue-delay", 5*time.Minute,
+		"The maximum delay before reconciling again an object waiting for another object or in error")
+	flag.IntVar(&shards, "shards", 1, "The number of shards splitting the stacks between instances of the operator")
+	flag.IntVar(&shard, "shard", 0, "The shard handled by this instance of the operator, from 0 to shards-1")
+	flag.StringVar(&stackSelector, "stack-selector", "", "A label selector restricting the stacks handled by the operator")
+	flag.StringVar(&stackNamePrefix, "stack-name-prefix", "", "A prefix restricting the names of the stacks handled by the operator")
+	flag.BoolVar(&disableWebhooks, "disable-webhooks", false,
+		"Disable the webhook server, the conversion between the versions of the CRDs is not available")
 	opts := zap.Options{
 		Development: false,
 	}
@@ -80,6 +103,47 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	sharding := core.Sharding{
+		Shards: shards,
+		Shard:  shard,
+	}
+	leaderElectionID := "6e1085e1.com"
+	if sharding.Enabled() {
+		if shard < 0 || shard >= shards {
+			setupLog.Error(fmt.Errorf("shard %d out of range [0, %d)", shard, shards), "invalid sharding configuration")
+			os.Exit(1)
+		}
+		// Each shard elects its own leader
+		leaderElectionID = fmt.Sprintf("6e1085e1.com-shard-%d", shard)
+	}
+
+	selector, err := labels.Parse(stackSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid stack selector")
+		os.Exit(1)
+	}
+	stacks := core.StackSelector{
+		Labels:     selector,
+		NamePrefix: stackNamePrefix,
+	}
+	cacheOptions := cache.Options{}
+	if stacks.Enabled() {
+		// Operators handling different stacks must not share the same leader
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(selector.String() + "/" + stackNamePrefix))
+		leaderElectionID = fmt.Sprintf("%s-%x", leaderElectionID, h.Sum32())
+
+		// Stacks not matching the label selector are not loaded in the cache.
+		// The name prefix cannot be applied, as field selectors only support exact names,
+		// and the other kinds do not carry the labels of their stack, so they are all cached
+		// and filtered when the events are received.
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&formancev1beta1.Stack{}: {
+				Label: selector,
+			},
+		}
+	}
+
 	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{
 		InsecureSkipVerify: true,
 	}
@@ -91,11 +155,12 @@ func main() {
 				Unstructured: true,
 			},
 		},
+		Cache:                  cacheOptions,
 		Scheme:                 scheme,
 		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "6e1085e1.com",
+		LeaderElectionID:       leaderElectionID,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -114,10 +179,13 @@ func main() {
 	}
 
 	platform := core.Platform{
-		Region:        region,
-		Environment:   env,
-		LicenceSecret: licenceSecret,
-		UtilsVersion:  utilsVersion,
+		Region:          region,
+		Environment:     env,
+		LicenceSecret:   licenceSecret,
+		UtilsVersion:    utilsVersion,
+		MaxRequeueDelay: maxRequeueDelay,
+		Sharding:        sharding,
+		StackSelector:   stacks,
 	}
 
 	if licenceSecret != "" {
@@ -126,11 +194,32 @@ func main() {
 		setupLog.Info("licence management disabled")
 	}
 
+	if stacks.Enabled() {
+		setupLog.Info("stack selection enabled", "selector", selector.String(), "prefix", stackNamePrefix)
+	}
+	if sharding.Enabled() {
+		setupLog.Info("sharding enabled", "shard", shard, "shards", shards)
+	}
+
 	if err := core.Setup(mgr, platform); err != nil {
 		setupLog.Error(err, "unable to create controllers")
 		os.Exit(1)
 	}
 
+	if err := core.SetupStackHealthEndpoint(mgr, stackHealthAddr); err != nil {
+		setupLog.Error(err, "unable to set up stack health endpoint")
+		os.Exit(1)
+	}
+
+	if !disableWebhooks {
+		if err := core.SetupConversionWebhooks(mgr); err != nil {
+			setupLog.Error(err, "unable to create conversion webhooks")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("webhooks disabled")
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
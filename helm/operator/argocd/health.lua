@@ -0,0 +1,68 @@
+-- Health check of the formance.com resources, for Argo CD.
+-- It relies on the Ready, Progressing and Degraded conditions reported by the operator.
+local hs = {}
+
+local function describe(condition)
+  if condition.reason == nil or condition.reason == "" then
+    return condition.message
+  end
+  return condition.reason .. ": " .. (condition.message or "")
+end
+
+if obj.status == nil then
+  hs.status = "Progressing"
+  hs.message = "Waiting for the operator to reconcile the resource"
+  return hs
+end
+
+local ready = nil
+local progressing = nil
+local degraded = nil
+if obj.status.conditions ~= nil then
+  for _, condition in ipairs(obj.status.conditions) do
+    if condition.type == "Ready" then
+      ready = condition
+    elseif condition.type == "Progressing" then
+      progressing = condition
+    elseif condition.type == "Degraded" then
+      degraded = condition
+    end
+  end
+end
+
+-- Resources reconciled by an operator without the standard conditions
+if ready == nil then
+  if obj.status.ready then
+    hs.status = "Healthy"
+  else
+    hs.status = "Progressing"
+  end
+  hs.message = obj.status.info
+  return hs
+end
+
+if ready.observedGeneration ~= nil and obj.metadata.generation ~= nil and ready.observedGeneration < obj.metadata.generation then
+  hs.status = "Progressing"
+  hs.message = "Waiting for the operator to reconcile the last changes"
+  return hs
+end
+
+if degraded ~= nil and degraded.status == "True" then
+  hs.status = "Degraded"
+  hs.message = describe(degraded)
+  return hs
+end
+
+if ready.status == "True" then
+  hs.status = "Healthy"
+  hs.message = ready.message
+  return hs
+end
+
+hs.status = "Progressing"
+if progressing ~= nil and progressing.status == "True" then
+  hs.message = describe(progressing)
+else
+  hs.message = describe(ready)
+end
+return hs
@@ -0,0 +1,49 @@
+package core
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+// setStandardConditions reports the result of a reconciliation on the Ready, Progressing and Degraded conditions:
+//   - a successful reconciliation sets Ready
+//   - a pending error sets Progressing, the object waits for other objects
+//   - any other error sets Degraded
+func setStandardConditions(object v1beta1.Object, err error) {
+	var (
+		ready       = metav1.ConditionFalse
+		progressing = metav1.ConditionFalse
+		degraded    = metav1.ConditionFalse
+		message     = "Object reconciled"
+	)
+	switch {
+	case err == nil:
+		ready = metav1.ConditionTrue
+	case IsPendingError(err):
+		progressing = metav1.ConditionTrue
+	default:
+		degraded = metav1.ConditionTrue
+	}
+	if err != nil {
+		message = err.Error()
+	}
+
+	reason := getConditionReason(err)
+	for _, condition := range []struct {
+		conditionType string
+		status        metav1.ConditionStatus
+	}{
+		{v1beta1.ConditionTypeReady, ready},
+		{v1beta1.ConditionTypeProgressing, progressing},
+		{v1beta1.ConditionTypeDegraded, degraded},
+	} {
+		object.GetConditions().Set(v1beta1.Condition{
+			Type:               condition.conditionType,
+			Status:             condition.status,
+			ObservedGeneration: object.GetGeneration(),
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestSetStandardConditions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                string
+		err                 error
+		expectedReady       metav1.ConditionStatus
+		expectedProgressing metav1.ConditionStatus
+		expectedDegraded    metav1.ConditionStatus
+		expectedReason      string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:                "reconciled",
+			expectedReady:       metav1.ConditionTrue,
+			expectedProgressing: metav1.ConditionFalse,
+			expectedDegraded:    metav1.ConditionFalse,
+			expectedReason:      v1beta1.ReasonReconciled,
+		},
+		{
+			name:                "pending",
+			err:                 NewPendingError().WithMessage("waiting for database"),
+			expectedReady:       metav1.ConditionFalse,
+			expectedProgressing: metav1.ConditionTrue,
+			expectedDegraded:    metav1.ConditionFalse,
+			expectedReason:      v1beta1.ReasonPending,
+		},
+		{
+			name:                "stack not found",
+			err:                 NewStackNotFoundError(),
+			expectedReady:       metav1.ConditionFalse,
+			expectedProgressing: metav1.ConditionFalse,
+			expectedDegraded:    metav1.ConditionTrue,
+			expectedReason:      v1beta1.ReasonStackNotFound,
+		},
+		{
+			name:                "missing settings",
+			err:                 NewMissingSettingsError("missing broker configuration"),
+			expectedReady:       metav1.ConditionFalse,
+			expectedProgressing: metav1.ConditionFalse,
+			expectedDegraded:    metav1.ConditionTrue,
+			expectedReason:      v1beta1.ReasonMissingSettings,
+		},
+		{
+			name:                "application error",
+			err:                 NewApplicationError().WithMessage("invalid configuration"),
+			expectedReady:       metav1.ConditionFalse,
+			expectedProgressing: metav1.ConditionFalse,
+			expectedDegraded:    metav1.ConditionTrue,
+			expectedReason:      v1beta1.ReasonReconcileError,
+		},
+		{
+			name:                "internal error",
+			err:                 errors.New("connection refused"),
+			expectedReady:       metav1.ConditionFalse,
+			expectedProgressing: metav1.ConditionFalse,
+			expectedDegraded:    metav1.ConditionTrue,
+			expectedReason:      v1beta1.ReasonInternalError,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ledger := &v1beta1.Ledger{}
+			ledger.Generation = 2
+			setStandardConditions(ledger, tc.err)
+
+			for conditionType, expectedStatus := range map[string]metav1.ConditionStatus{
+				v1beta1.ConditionTypeReady:       tc.expectedReady,
+				v1beta1.ConditionTypeProgressing: tc.expectedProgressing,
+				v1beta1.ConditionTypeDegraded:    tc.expectedDegraded,
+			} {
+				condition := ledger.GetConditions().Get(conditionType)
+				require.NotNil(t, condition)
+				require.Equal(t, expectedStatus, condition.Status, conditionType)
+				require.Equal(t, tc.expectedReason, condition.Reason, conditionType)
+				require.Equal(t, int64(2), condition.ObservedGeneration, conditionType)
+				if tc.err != nil {
+					require.Equal(t, tc.err.Error(), condition.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestSetStandardConditionsTransitionTime(t *testing.T) {
+	t.Parallel()
+
+	ledger := &v1beta1.Ledger{}
+	setStandardConditions(ledger, NewPendingError())
+	require.Len(t, *ledger.GetConditions(), 3)
+
+	past := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	for i := range ledger.Status.Conditions {
+		ledger.Status.Conditions[i].LastTransitionTime = past
+	}
+
+	setStandardConditions(ledger, nil)
+	require.Len(t, *ledger.GetConditions(), 3)
+	// Ready and Progressing changed
+	require.NotEqual(t, past, ledger.GetConditions().Get(v1beta1.ConditionTypeReady).LastTransitionTime)
+	require.NotEqual(t, past, ledger.GetConditions().Get(v1beta1.ConditionTypeProgressing).LastTransitionTime)
+	// Degraded is still false
+	require.Equal(t, past, ledger.GetConditions().Get(v1beta1.ConditionTypeDegraded).LastTransitionTime)
+}
+
+func TestForObjectControllerConditions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		conditionType string
+		expectPending bool
+	}
+	for _, tc := range []testCase{
+		{name: "failed condition", conditionType: "DatabaseReady", expectPending: true},
+		{name: "informational condition", conditionType: v1beta1.ConditionTypeOIDCReachable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth := &v1beta1.Auth{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+			}
+			controller := ForObjectController(func(ctx Context, reconcilerOptions *ReconcilerOptions[*v1beta1.Auth], auth *v1beta1.Auth) error {
+				auth.GetConditions().AppendOrReplace(
+					*v1beta1.NewCondition(tc.conditionType, auth.Generation).Fail("failure"),
+					v1beta1.ConditionTypeMatch(tc.conditionType),
+				)
+				return nil
+			})
+
+			err := controller(nil, nil, auth)
+			if tc.expectPending {
+				require.True(t, IsPendingError(err))
+				require.False(t, auth.Status.Ready)
+			} else {
+				require.NoError(t, err)
+				require.True(t, auth.Status.Ready)
+			}
+		})
+	}
+}
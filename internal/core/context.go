@@ -17,7 +17,17 @@ type Context interface {
 
 type defaultContext struct {
 	context.Context
-	mgr Manager
+	mgr    Manager
+	drifts *[]Drift
+}
+
+func (d defaultContext) reportDrift(drift Drift) {
+	*d.drifts = append(*d.drifts, drift)
+}
+
+// getDrifts returns the drifts detected on the objects updated using the context
+func (d defaultContext) getDrifts() []Drift {
+	return *d.drifts
 }
 
 func (d defaultContext) GetAPIReader() client.Reader {
@@ -42,5 +52,6 @@ func NewContext(mgr Manager, ctx context.Context) *defaultContext {
 	return &defaultContext{
 		Context: ctx,
 		mgr:     mgr,
+		drifts:  &[]Drift{},
 	}
 }
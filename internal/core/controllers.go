@@ -29,35 +29,39 @@ func ForObjectController[T v1beta1.Object](controller ObjectController[T]) Objec
 				object.SetReady(true)
 				object.SetError("Up to date")
 			}
+			setStandardConditions(object, err)
 		}
 
-		var reconcilerError error
+		// Application errors are returned to allow the reconciler to report them,
+		// they are retried according to the RequeuePolicies of the reconciler
 		err := controller(ctx, reconcilerOptions, object)
 		if err != nil {
 			setStatus(err)
-			if !IsApplicationError(err) {
-				reconcilerError = err
-			}
-		} else {
-			for _, condition := range *object.GetConditions() {
-				if condition.ObservedGeneration != object.GetGeneration() {
-					continue
-				}
+			return err
+		}
 
-				if condition.Status != metav1.ConditionTrue {
-					str := condition.Type
-					if condition.Reason != "" {
-						str += "/" + condition.Reason
-					}
+		for _, condition := range *object.GetConditions() {
+			if condition.ObservedGeneration != object.GetGeneration() || v1beta1.IsStandardCondition(condition) ||
+				v1beta1.IsInformationalCondition(condition) {
+				continue
+			}
 
-					setStatus(NewPendingError().WithMessage("%s", "pending condition: "+str))
-					return nil
+			if condition.Status != metav1.ConditionTrue {
+				str := condition.Type
+				if condition.Reason != "" {
+					str += "/" + condition.Reason
 				}
+
+				err := NewPendingError().
+					WithMessage("%s", "pending condition: "+str).
+					WithReason(v1beta1.ReasonWaitingForCondition)
+				setStatus(err)
+				return err
 			}
-			setStatus(nil)
 		}
+		setStatus(nil)
 
-		return reconcilerError
+		return nil
 	}
 }
 
@@ -102,9 +106,23 @@ func ForStackDependency[T v1beta1.Dependent](ctrl StackDependentObjectController
 type ModuleController[T v1beta1.Module] func(ctx Context, stack *v1beta1.Stack, reconcilerOptions *ReconcilerOptions[T], req T, version string) error
 
 func ForModule[T v1beta1.Module](underlyingController ModuleController[T]) StackDependentObjectController[T] {
+	return forModule(func(ctx Context, stack *v1beta1.Stack, t T) (string, error) {
+		return GetModuleVersion(ctx, stack, t)
+	}, underlyingController)
+}
+
+// ForUnversionedModule handles modules not released with the stack, like custom modules.
+// The version of those modules is not resolved from the stack, and is passed empty to the controller.
+func ForUnversionedModule[T v1beta1.Module](underlyingController ModuleController[T]) StackDependentObjectController[T] {
+	return forModule(func(ctx Context, stack *v1beta1.Stack, t T) (string, error) {
+		return "", nil
+	}, underlyingController)
+}
+
+func forModule[T v1beta1.Module](getVersion func(ctx Context, stack *v1beta1.Stack, t T) (string, error), underlyingController ModuleController[T]) StackDependentObjectController[T] {
 	return func(ctx Context, stack *v1beta1.Stack, reconcilerOptions *ReconcilerOptions[T], t T) error {
 
-		moduleVersion, err := GetModuleVersion(ctx, stack, t)
+		moduleVersion, err := getVersion(ctx, stack, t)
 		if err != nil {
 			return err
 		}
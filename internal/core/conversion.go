@@ -0,0 +1,31 @@
+package core
+
+import (
+	"reflect"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// SetupConversionWebhooks registers the conversion webhook of the objects available in several versions.
+// The objects are detected using the hub version registered in the scheme of the manager.
+func SetupConversionWebhooks(mgr ctrl.Manager) error {
+	for _, rtype := range mgr.GetScheme().AllKnownTypes() {
+		object, ok := reflect.New(rtype).Interface().(client.Object)
+		if !ok {
+			continue
+		}
+		if _, ok := object.(conversion.Hub); !ok {
+			continue
+		}
+
+		if err := ctrl.NewWebhookManagedBy(mgr).
+			For(object).
+			Complete(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package coretest provides a core.Context for the unit tests of the reconcilers.
+package coretest
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/formancehq/operator/v3/internal/core"
+)
+
+// Context is a core.Context for the unit tests, the client and the scheme are nil unless provided
+type Context struct {
+	context.Context
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+func (c Context) GetClient() client.Client    { return c.Client }
+func (c Context) GetScheme() *runtime.Scheme  { return c.Scheme }
+func (c Context) GetAPIReader() client.Reader { return c.Client }
+func (c Context) GetPlatform() core.Platform  { return core.Platform{} }
+
+var _ core.Context = Context{}
+
+// NewContext returns a context for the reconcilers only using the API of a module
+func NewContext() Context {
+	return Context{
+		Context: context.Background(),
+	}
+}
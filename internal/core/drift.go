@@ -0,0 +1,305 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+const (
+	// desiredHashAnnotation stores the hash of the state produced by the mutators on the last update of an object
+	desiredHashAnnotation = "formance.com/desired-hash"
+
+	// ignoredDriftHashAnnotation stores the hash of the content of an object annotated with formance.com/ignore-drift,
+	// when its drift has been reported, so the same drift is not reported again on each reconciliation
+	ignoredDriftHashAnnotation = "formance.com/ignored-drift-hash"
+
+	// maxDriftFields limits the number of fields reported for a drifted object
+	maxDriftFields = 5
+)
+
+// Drift is a manual change detected on an object managed by the operator
+type Drift struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Fields are the paths of the fields which differ from the state produced by the operator
+	Fields []string
+	// Ignored indicates the object is annotated with formance.com/ignore-drift, the change is not reverted
+	Ignored bool
+	// Known indicates the ignored drift has already been reported by a previous reconciliation
+	Known bool
+
+	// hash is the hash of the content of an ignored object, recorded once the drift is reported
+	hash string
+}
+
+func (d Drift) String() string {
+	name := d.Name
+	if d.Namespace != "" {
+		name = d.Namespace + "/" + name
+	}
+	fields := d.Fields
+	if len(fields) > maxDriftFields {
+		fields = append(fields[:maxDriftFields:maxDriftFields], fmt.Sprintf("and %d more", len(d.Fields)-maxDriftFields))
+	}
+	ret := fmt.Sprintf("%s %s changed outside of the operator: %s", d.Kind, name, strings.Join(fields, ", "))
+	if d.Ignored {
+		ret += " (not reverted)"
+	}
+	return ret
+}
+
+type driftReporter interface {
+	reportDrift(drift Drift)
+}
+
+// detectDrift compares an existing object with the state produced by the mutators.
+// The object has drifted when the mutators produce the same state as on the last update, but the
+// existing object differs: someone else changed it. When the mutators produce a new state, the object
+// is updated without reporting a drift.
+// It returns the drift, if any, and updates the hash annotation of the desired object.
+func detectDrift(scheme *runtime.Scheme, existing, desired client.Object) (*Drift, error) {
+	desiredContent, err := driftContent(desired)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := hashContent(desiredContent)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		annotations := desired.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[desiredHashAnnotation] = hash
+		delete(annotations, ignoredDriftHashAnnotation)
+		desired.SetAnnotations(annotations)
+	}()
+
+	if existing == nil || existing.GetAnnotations()[desiredHashAnnotation] != hash {
+		return nil, nil
+	}
+
+	existingContent, err := driftContent(existing)
+	if err != nil {
+		return nil, err
+	}
+	fields := diffFields("", desiredContent, existingContent)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	sort.Strings(fields)
+
+	gvk, err := apiutil.GVKForObject(existing, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := &Drift{
+		Kind:      gvk.Kind,
+		Namespace: existing.GetNamespace(),
+		Name:      existing.GetName(),
+		Fields:    fields,
+		Ignored:   existing.GetAnnotations()[v1beta1.IgnoreDriftAnnotation] == "true",
+	}
+	if drift.Ignored {
+		drift.hash, err = hashContent(existingContent)
+		if err != nil {
+			return nil, err
+		}
+		drift.Known = existing.GetAnnotations()[ignoredDriftHashAnnotation] == drift.hash
+	}
+
+	return drift, nil
+}
+
+// markIgnoredDrift records the drift on the ignored object, so it is not reported again while its content does not change
+func markIgnoredDrift(object client.Object, drift Drift) {
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ignoredDriftHashAnnotation] = drift.hash
+	object.SetAnnotations(annotations)
+}
+
+// newDrifts returns the drifts not reported by a previous reconciliation
+func newDrifts(drifts []Drift) []Drift {
+	ret := make([]Drift, 0, len(drifts))
+	for _, drift := range drifts {
+		if !drift.Known {
+			ret = append(ret, drift)
+		}
+	}
+	return ret
+}
+
+// driftContent returns the content of an object compared to detect drifts.
+// Metadata and status are not compared, they are updated by the api server and other controllers.
+func driftContent(object client.Object) (map[string]any, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+
+	// The content of unstructured objects is not copied by the converter
+	ret := make(map[string]any, len(content))
+	for key, value := range content {
+		switch key {
+		case "apiVersion", "kind", "metadata", "status":
+		default:
+			ret[key] = value
+		}
+	}
+
+	return ret, nil
+}
+
+func hashContent(content map[string]any) (string, error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:16], nil
+}
+
+// mergeKeys are the fields identifying the items of the lists of objects, like the merge keys of the strategic merge patch
+var mergeKeys = []string{"name", "containerPort", "port", "mountPath", "key"}
+
+// mergeKey returns the field identifying the items of a list, if all the items are objects having a distinct value for it
+func mergeKey(items []any) string {
+	for _, key := range mergeKeys {
+		values := map[string]struct{}{}
+		for _, item := range items {
+			object, ok := item.(map[string]any)
+			if !ok || object[key] == nil {
+				break
+			}
+			values[jsonValue(object[key])] = struct{}{}
+		}
+		if len(values) == len(items) {
+			return key
+		}
+	}
+	return ""
+}
+
+func findItem(items []any, key string, value any) any {
+	for _, item := range items {
+		if object, ok := item.(map[string]any); ok && jsonValue(object[key]) == jsonValue(value) {
+			return object
+		}
+	}
+	return nil
+}
+
+// jsonValue returns the JSON representation of a value, as numbers of unstructured objects may have different types
+func jsonValue(value any) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// diffFields returns the paths of the fields defined in desired with a different value in existing.
+// Only the fields set by the operator are compared: fields only defined in existing, or empty in desired,
+// are ignored, as they are generally defaulted by the api server or set by other controllers.
+// The items of the lists of objects are matched using their merge key (name, port...), so the items added
+// by others are ignored, while the lists of values, like the args of a container, must be equal.
+func diffFields(path string, desired, existing any) []string {
+	if desired == nil {
+		return nil
+	}
+
+	switch desired := desired.(type) {
+	case map[string]any:
+		if len(desired) == 0 {
+			return nil
+		}
+		existing, ok := existing.(map[string]any)
+		if !ok {
+			return []string{path}
+		}
+		ret := make([]string, 0)
+		for key, value := range desired {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			ret = append(ret, diffFields(fieldPath, value, existing[key])...)
+		}
+		return ret
+	case []any:
+		if len(desired) == 0 {
+			return nil
+		}
+		existing, ok := existing.([]any)
+		if !ok {
+			return []string{path}
+		}
+		if key := mergeKey(desired); key != "" {
+			ret := make([]string, 0)
+			for _, item := range desired {
+				value := item.(map[string]any)[key]
+				itemPath := fmt.Sprintf("%s[%s=%v]", path, key, value)
+				existingItem := findItem(existing, key, value)
+				if existingItem == nil {
+					ret = append(ret, itemPath)
+					continue
+				}
+				ret = append(ret, diffFields(itemPath, item, existingItem)...)
+			}
+			return ret
+		}
+		if len(existing) != len(desired) {
+			return []string{path}
+		}
+		ret := make([]string, 0)
+		for i := range desired {
+			ret = append(ret, diffFields(fmt.Sprintf("%s[%d]", path, i), desired[i], existing[i])...)
+		}
+		return ret
+	default:
+		if jsonValue(desired) != jsonValue(existing) {
+			return []string{path}
+		}
+		return nil
+	}
+}
+
+// setDriftCondition reports the drifts detected during a reconciliation on the DriftDetected condition.
+// The condition is removed once a reconciliation completes without drift.
+func setDriftCondition(object v1beta1.Object, drifts []Drift, err error) {
+	if len(drifts) == 0 {
+		if err == nil {
+			object.GetConditions().Delete(v1beta1.ConditionTypeMatch(v1beta1.ConditionTypeDriftDetected))
+		}
+		return
+	}
+
+	reason := v1beta1.ReasonDriftReverted
+	messages := make([]string, 0, len(drifts))
+	for _, drift := range drifts {
+		if drift.Ignored {
+			reason = v1beta1.ReasonDriftIgnored
+		}
+		messages = append(messages, drift.String())
+	}
+
+	object.GetConditions().Set(v1beta1.Condition{
+		Type:               v1beta1.ConditionTypeDriftDetected,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: object.GetGeneration(),
+		Reason:             reason,
+		Message:            strings.Join(messages, "; "),
+	})
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+type driftTestContext struct {
+	context.Context
+	client client.Client
+	scheme *runtime.Scheme
+	drifts []Drift
+}
+
+func (d *driftTestContext) GetClient() client.Client    { return d.client }
+func (d *driftTestContext) GetScheme() *runtime.Scheme  { return d.scheme }
+func (d *driftTestContext) GetAPIReader() client.Reader { return d.client }
+func (d *driftTestContext) GetPlatform() Platform       { return Platform{} }
+func (d *driftTestContext) reportDrift(drift Drift)     { d.drifts = append(d.drifts, drift) }
+
+func TestCreateOrUpdateDetectsDrift(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	ctx := &driftTestContext{
+		Context: context.Background(),
+		client:  fake.NewClientBuilder().WithScheme(scheme).Build(),
+		scheme:  scheme,
+	}
+	key := types.NamespacedName{Namespace: "acme", Name: "config"}
+	withData := func(value string) ObjectMutator[*corev1.ConfigMap] {
+		return func(t *corev1.ConfigMap) error {
+			t.Data = map[string]string{
+				"key": value,
+			}
+			return nil
+		}
+	}
+	edit := func(value string, annotations map[string]string) {
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, ctx.client.Get(ctx, key, configMap))
+		configMap.Data["key"] = value
+		for k, v := range annotations {
+			configMap.Annotations[k] = v
+		}
+		require.NoError(t, ctx.client.Update(ctx, configMap))
+	}
+	get := func() *corev1.ConfigMap {
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, ctx.client.Get(ctx, key, configMap))
+		return configMap
+	}
+
+	// Creation is not a drift
+	_, _, err := CreateOrUpdate(ctx, key, withData("v1"))
+	require.NoError(t, err)
+	require.Empty(t, ctx.drifts)
+	require.NotEmpty(t, get().Annotations[desiredHashAnnotation])
+
+	// A change of the desired state is not a drift
+	_, _, err = CreateOrUpdate(ctx, key, withData("v2"))
+	require.NoError(t, err)
+	require.Empty(t, ctx.drifts)
+
+	// A manual change is reported and reverted
+	edit("manual", nil)
+	_, _, err = CreateOrUpdate(ctx, key, withData("v2"))
+	require.NoError(t, err)
+	require.Equal(t, []Drift{{
+		Kind:      "ConfigMap",
+		Namespace: "acme",
+		Name:      "config",
+		Fields:    []string{"data.key"},
+	}}, ctx.drifts)
+	require.Equal(t, "v2", get().Data["key"])
+
+	// A manual change of an object annotated with formance.com/ignore-drift is reported and kept
+	ctx.drifts = nil
+	edit("manual", map[string]string{
+		v1beta1.IgnoreDriftAnnotation: "true",
+	})
+	_, _, err = CreateOrUpdate(ctx, key, withData("v2"))
+	require.NoError(t, err)
+	require.Len(t, ctx.drifts, 1)
+	require.True(t, ctx.drifts[0].Ignored)
+	require.Equal(t, "manual", get().Data["key"])
+
+	// The same ignored drift is reported as known on the next reconciliations
+	ctx.drifts = nil
+	_, _, err = CreateOrUpdate(ctx, key, withData("v2"))
+	require.NoError(t, err)
+	require.Len(t, ctx.drifts, 1)
+	require.True(t, ctx.drifts[0].Known)
+	require.Empty(t, newDrifts(ctx.drifts))
+
+	// A new manual change of the ignored object is reported again
+	ctx.drifts = nil
+	edit("other", nil)
+	_, _, err = CreateOrUpdate(ctx, key, withData("v2"))
+	require.NoError(t, err)
+	require.Len(t, ctx.drifts, 1)
+	require.False(t, ctx.drifts[0].Known)
+	require.Len(t, newDrifts(ctx.drifts), 1)
+	require.Equal(t, "other", get().Data["key"])
+
+	// A new desired state is applied on ignored objects
+	ctx.drifts = nil
+	_, _, err = CreateOrUpdate(ctx, key, withData("v3"))
+	require.NoError(t, err)
+	require.Empty(t, ctx.drifts)
+	require.Equal(t, "v3", get().Data["key"])
+	require.NotContains(t, get().Annotations, ignoredDriftHashAnnotation)
+}
+
+func TestDiffFields(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		desired  map[string]any
+		existing map[string]any
+		expected []string
+	}
+	for _, tc := range []testCase{
+		{
+			name:     "equal",
+			desired:  map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+			existing: map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+		},
+		{
+			name:     "number types",
+			desired:  map[string]any{"spec": map[string]any{"replicas": 1}},
+			existing: map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+		},
+		{
+			name:     "defaulted fields",
+			desired:  map[string]any{"spec": map[string]any{"replicas": int64(1), "selector": map[string]any{}}},
+			existing: map[string]any{"spec": map[string]any{"replicas": int64(1), "revisionHistoryLimit": int64(10)}},
+		},
+		{
+			name:     "changed field",
+			desired:  map[string]any{"spec": map[string]any{"replicas": int64(1)}},
+			existing: map[string]any{"spec": map[string]any{"replicas": int64(3)}},
+			expected: []string{"spec.replicas"},
+		},
+		{
+			name: "changed list item",
+			desired: map[string]any{"containers": []any{
+				map[string]any{"name": "api", "image": "ledger:v2.2.0"},
+			}},
+			existing: map[string]any{"containers": []any{
+				map[string]any{"name": "api", "image": "ledger:latest", "imagePullPolicy": "Always"},
+			}},
+			expected: []string{"containers[name=api].image"},
+		},
+		{
+			name:     "list item added by others",
+			desired:  map[string]any{"env": []any{map[string]any{"name": "DEBUG"}}},
+			existing: map[string]any{"env": []any{map[string]any{"name": "OTHER"}, map[string]any{"name": "DEBUG"}}},
+		},
+		{
+			name:     "removed list item",
+			desired:  map[string]any{"env": []any{map[string]any{"name": "DEBUG"}, map[string]any{"name": "OTHER"}}},
+			existing: map[string]any{"env": []any{map[string]any{"name": "DEBUG"}}},
+			expected: []string{"env[name=OTHER]"},
+		},
+		{
+			name: "defaulted fields of list items",
+			desired: map[string]any{"ports": []any{
+				map[string]any{"port": 8080, "name": "http"},
+			}},
+			existing: map[string]any{"ports": []any{
+				map[string]any{"port": int64(8080), "name": "http", "protocol": "TCP", "targetPort": int64(8080)},
+			}},
+		},
+		{
+			name:     "items matched by port",
+			desired:  map[string]any{"ports": []any{map[string]any{"containerPort": 8080}}},
+			existing: map[string]any{"ports": []any{map[string]any{"containerPort": int64(9090)}, map[string]any{"containerPort": int64(8080)}}},
+		},
+		{
+			name:     "changed list of values",
+			desired:  map[string]any{"args": []any{"serve"}},
+			existing: map[string]any{"args": []any{"serve", "--debug"}},
+			expected: []string{"args"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fields := diffFields("", tc.desired, tc.existing)
+			if len(tc.expected) == 0 {
+				require.Empty(t, fields)
+			} else {
+				require.Equal(t, tc.expected, fields)
+			}
+		})
+	}
+}
+
+func TestDriftString(t *testing.T) {
+	t.Parallel()
+
+	drift := Drift{
+		Kind:      "Deployment",
+		Namespace: "acme",
+		Name:      "ledger",
+		Fields:    []string{"a", "b", "c", "d", "e", "f", "g"},
+		Ignored:   true,
+	}
+	require.Equal(t, "Deployment acme/ledger changed outside of the operator: a, b, c, d, e, and 2 more (not reverted)", drift.String())
+	require.Len(t, drift.Fields, 7)
+}
+
+func TestSetDriftCondition(t *testing.T) {
+	t.Parallel()
+
+	ledger := &v1beta1.Ledger{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 2,
+		},
+	}
+	drifts := []Drift{{
+		Kind:      "Deployment",
+		Namespace: "acme",
+		Name:      "ledger",
+		Fields:    []string{"spec.replicas"},
+	}}
+
+	setDriftCondition(ledger, drifts, nil)
+	condition := ledger.GetConditions().Get(v1beta1.ConditionTypeDriftDetected)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, v1beta1.ReasonDriftReverted, condition.Reason)
+	require.Equal(t, int64(2), condition.ObservedGeneration)
+	require.Equal(t, "Deployment acme/ledger changed outside of the operator: spec.replicas", condition.Message)
+
+	// The condition is kept when the reconciliation fails before checking all the objects
+	setDriftCondition(ledger, nil, NewPendingError())
+	require.NotNil(t, ledger.GetConditions().Get(v1beta1.ConditionTypeDriftDetected))
+
+	setDriftCondition(ledger, nil, nil)
+	require.Nil(t, ledger.GetConditions().Get(v1beta1.ConditionTypeDriftDetected))
+}
@@ -2,12 +2,20 @@ package core
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 )
 
 type ApplicationError struct {
 	message string
+	pending bool
+	// reason is reported on the standard conditions of the object
+	reason string
+	// requeueAfter overrides the requeue policy of the reconciler when set
+	requeueAfter *time.Duration
 }
 
 func (e *ApplicationError) Error() string {
@@ -24,22 +32,81 @@ func (e *ApplicationError) WithMessage(msg string, args ...any) *ApplicationErro
 	return e
 }
 
+// WithReason sets the machine-readable reason reported on the Ready, Progressing and Degraded conditions
+func (e *ApplicationError) WithReason(reason string) *ApplicationError {
+	e.reason = reason
+	return e
+}
+
+// WithRequeueAfter indicates the object must be reconciled again after the given delay,
+// whatever the requeue policy of the reconciler
+func (e *ApplicationError) WithRequeueAfter(d time.Duration) *ApplicationError {
+	e.requeueAfter = &d
+	return e
+}
+
+// OnlyOnWatchEvent indicates the object must not be reconciled again until a watched object changes
+func (e *ApplicationError) OnlyOnWatchEvent() *ApplicationError {
+	return e.WithRequeueAfter(0)
+}
+
 func NewApplicationError() *ApplicationError {
 	return &ApplicationError{}
 }
 
 func NewStackNotFoundError() *ApplicationError {
-	return NewApplicationError().WithMessage("stack not found")
+	return NewApplicationError().
+		WithMessage("stack not found").
+		WithReason(v1beta1.ReasonStackNotFound)
 }
 
 func NewPendingError() *ApplicationError {
-	return NewApplicationError().WithMessage("pending")
+	ret := NewApplicationError().
+		WithMessage("pending").
+		WithReason(v1beta1.ReasonPending)
+	ret.pending = true
+	return ret
 }
 
 func NewMissingSettingsError(msg string) *ApplicationError {
-	return NewApplicationError().WithMessage("%s", msg)
+	return NewApplicationError().
+		WithMessage("%s", msg).
+		WithReason(v1beta1.ReasonMissingSettings)
 }
 
 func IsApplicationError(err error) bool {
 	return errors.Is(err, &ApplicationError{})
 }
+
+// IsPendingError returns true if the error indicates the object is waiting for another object
+func IsPendingError(err error) bool {
+	applicationError := &ApplicationError{}
+	return errors.As(err, &applicationError) && applicationError.pending
+}
+
+// getConditionReason returns the reason reported on the standard conditions of an object after a reconciliation
+func getConditionReason(err error) string {
+	if err == nil {
+		return v1beta1.ReasonReconciled
+	}
+
+	applicationError := &ApplicationError{}
+	if !errors.As(err, &applicationError) {
+		return v1beta1.ReasonInternalError
+	}
+	if applicationError.reason != "" {
+		return applicationError.reason
+	}
+	if applicationError.pending {
+		return v1beta1.ReasonPending
+	}
+	return v1beta1.ReasonReconcileError
+}
+
+func getRequeueHint(err error) (time.Duration, bool) {
+	applicationError := &ApplicationError{}
+	if !errors.As(err, &applicationError) || applicationError.requeueAfter == nil {
+		return 0, false
+	}
+	return *applicationError.requeueAfter, true
+}
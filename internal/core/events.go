@@ -0,0 +1,92 @@
+package core
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+const (
+	EventReasonReady            = "Ready"
+	EventReasonPending          = "Pending"
+	EventReasonReconcileError   = "ReconcileError"
+	EventReasonFinalizerBlocked = "FinalizerBlocked"
+	EventReasonDriftDetected    = "DriftDetected"
+)
+
+func getErrorReason(err error) string {
+	switch {
+	case IsApplicationError(err):
+		return ReconcileErrorReasonApplication
+	case apierrors.IsConflict(err):
+		return ReconcileErrorReasonConflict
+	default:
+		return ReconcileErrorReasonInternal
+	}
+}
+
+// getObjectStack returns the stack of an object, or an empty string for objects not related to a stack
+func getObjectStack(object client.Object) string {
+	switch object := object.(type) {
+	case *v1beta1.Stack:
+		return object.Name
+	case v1beta1.Dependent:
+		return object.GetStack()
+	default:
+		return ""
+	}
+}
+
+func isObjectReady(object client.Object) bool {
+	if object, ok := object.(interface {
+		IsReady() bool
+	}); ok {
+		return object.IsReady()
+	}
+	return false
+}
+
+func getObjectInfo(object client.Object) string {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return ""
+	}
+	info, _, _ := unstructured.NestedString(u, "status", "info")
+	return info
+}
+
+// recordReconcileEvent emits an event when the state of the object changes after a reconciliation.
+// Repeated reconciliations ending with the same state do not emit new events.
+func recordReconcileEvent(recorder record.EventRecorder, previous, object client.Object, err error) {
+	switch {
+	case err == nil:
+		if !isObjectReady(previous) {
+			recorder.Event(object, corev1.EventTypeNormal, EventReasonReady, "Object reconciled")
+		}
+	case getObjectInfo(previous) == err.Error() && !isObjectReady(previous):
+		// Same state as the previous reconciliation
+	case IsPendingError(err):
+		recorder.Event(object, corev1.EventTypeNormal, EventReasonPending, err.Error())
+	default:
+		recorder.Event(object, corev1.EventTypeWarning, EventReasonReconcileError, err.Error())
+	}
+}
+
+func recordFinalizerEvent(recorder record.EventRecorder, previous, object client.Object, finalizer string, err error) {
+	if getObjectInfo(previous) == err.Error() {
+		return
+	}
+	recorder.Eventf(object, corev1.EventTypeWarning, EventReasonFinalizerBlocked, "finalizer '%s': %s", finalizer, err)
+}
+
+// recordDriftEvents emits an event for each object changed outside of the operator
+func recordDriftEvents(recorder record.EventRecorder, object client.Object, drifts []Drift) {
+	for _, drift := range drifts {
+		recorder.Event(object, corev1.EventTypeWarning, EventReasonDriftDetected, drift.String())
+	}
+}
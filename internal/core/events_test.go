@@ -0,0 +1,94 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestRecordReconcileEvent(t *testing.T) {
+	t.Parallel()
+
+	newLedger := func(ready bool, info string) *v1beta1.Ledger {
+		ret := &v1beta1.Ledger{}
+		ret.Status.Ready = ready
+		ret.Status.Info = info
+		return ret
+	}
+
+	type testCase struct {
+		name          string
+		previous      *v1beta1.Ledger
+		err           error
+		expectedEvent string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "becoming ready",
+			previous:      newLedger(false, "pending"),
+			expectedEvent: "Normal Ready Object reconciled",
+		},
+		{
+			name:     "still ready",
+			previous: newLedger(true, "Up to date"),
+		},
+		{
+			name:          "becoming pending",
+			previous:      newLedger(true, "Up to date"),
+			err:           NewPendingError().WithMessage("waiting for database"),
+			expectedEvent: "Normal Pending waiting for database",
+		},
+		{
+			name:     "still pending with the same reason",
+			previous: newLedger(false, "waiting for database"),
+			err:      NewPendingError().WithMessage("waiting for database"),
+		},
+		{
+			name:          "pending with another reason",
+			previous:      newLedger(false, "waiting for database"),
+			err:           NewPendingError().WithMessage("waiting for broker"),
+			expectedEvent: "Normal Pending waiting for broker",
+		},
+		{
+			name:          "application error",
+			previous:      newLedger(true, "Up to date"),
+			err:           NewApplicationError().WithMessage("invalid setting"),
+			expectedEvent: "Warning ReconcileError invalid setting",
+		},
+		{
+			name:          "internal error",
+			previous:      newLedger(false, "pending"),
+			err:           errors.New("connection refused"),
+			expectedEvent: "Warning ReconcileError connection refused",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := record.NewFakeRecorder(1)
+			recordReconcileEvent(recorder, tc.previous, newLedger(tc.err == nil, ""), tc.err)
+
+			if tc.expectedEvent == "" {
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			require.Equal(t, tc.expectedEvent, <-recorder.Events)
+		})
+	}
+}
+
+func TestIsPendingError(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsPendingError(NewPendingError()))
+	require.True(t, IsPendingError(NewPendingError().WithMessage("waiting")))
+	require.False(t, IsPendingError(NewApplicationError().WithMessage("error")))
+	require.False(t, IsPendingError(errors.New("error")))
+	require.True(t, IsApplicationError(NewPendingError()))
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+const (
+	stackHealthPathPrefix        = "/stacks/"
+	stackHealthReadHeaderTimeout = 10 * time.Second
+)
+
+// StackHealth is the health of a stack, as returned by the endpoint /stacks/<name>/health
+type StackHealth struct {
+	Name    string                  `json:"name"`
+	Ready   bool                    `json:"ready"`
+	Info    string                  `json:"info,omitempty"`
+	Modules []v1beta1.ModuleSummary `json:"modules"`
+}
+
+// SetupStackHealthEndpoint serves the health of the stacks under /stacks/<name>/health, on a dedicated listener.
+// The endpoint is not authenticated, so the listener is only started when an address is provided ("0" disables it too),
+// and it must only be exposed to the clients allowed to read the health of all the stacks.
+func SetupStackHealthEndpoint(mgr ctrl.Manager, bindAddress string) error {
+	if bindAddress == "" || bindAddress == "0" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(stackHealthPathPrefix, NewStackHealthHandler(mgr.GetClient()))
+
+	return mgr.Add(&manager.Server{
+		Name: "stack health",
+		Server: &http.Server{
+			Addr:              bindAddress,
+			Handler:           mux,
+			ReadHeaderTimeout: stackHealthReadHeaderTimeout,
+		},
+	})
+}
+
+// NewStackHealthHandler returns the health of a stack, read from its status
+func NewStackHealthHandler(reader client.Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		name, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, stackHealthPathPrefix), "/health")
+		if !ok || name == "" || strings.Contains(name, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		stack := &v1beta1.Stack{}
+		if err := reader.Get(r.Context(), types.NamespacedName{
+			Name: name,
+		}, stack); err != nil {
+			if apierrors.IsNotFound(err) {
+				http.NotFound(w, r)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		health := StackHealth{
+			Name:    stack.Name,
+			Ready:   stack.Status.Ready,
+			Info:    stack.Status.Info,
+			Modules: stack.Status.Summary,
+		}
+		if health.Modules == nil {
+			health.Modules = []v1beta1.ModuleSummary{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(health)
+	})
+}
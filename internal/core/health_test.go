@@ -0,0 +1,85 @@
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestStackHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	stack := &v1beta1.Stack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acme",
+		},
+		Status: v1beta1.StackStatus{
+			Status: v1beta1.Status{
+				Info: "Pending modules: [Ledger]",
+			},
+			Summary: []v1beta1.ModuleSummary{{
+				Kind:    "Ledger",
+				Name:    "acme-ledger",
+				Version: "v2.2.0",
+				Error:   "database not ready",
+				Deployments: []v1beta1.DeploymentSummary{{
+					Name:     "ledger",
+					Replicas: 1,
+				}},
+				Database: &v1beta1.DependencySummary{
+					Error: "database creation pending",
+				},
+			}},
+		},
+	}
+
+	handler := NewStackHealthHandler(fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(stack).
+		Build())
+
+	type testCase struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}
+	for _, tc := range []testCase{
+		{name: "existing stack", method: http.MethodGet, path: "/stacks/acme/health", expectedStatus: http.StatusOK},
+		{name: "unknown stack", method: http.MethodGet, path: "/stacks/unknown/health", expectedStatus: http.StatusNotFound},
+		{name: "unknown path", method: http.MethodGet, path: "/stacks/acme", expectedStatus: http.StatusNotFound},
+		{name: "nested path", method: http.MethodGet, path: "/stacks/acme/ledger/health", expectedStatus: http.StatusNotFound},
+		{name: "invalid method", method: http.MethodPost, path: "/stacks/acme/health", expectedStatus: http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+			require.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stacks/acme/health", nil))
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	health := StackHealth{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&health))
+	require.Equal(t, StackHealth{
+		Name:    "acme",
+		Info:    stack.Status.Info,
+		Modules: stack.Status.Summary,
+	}, health)
+}
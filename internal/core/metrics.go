@@ -0,0 +1,99 @@
+package core
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	ReconcileErrorReasonApplication = "application"
+	ReconcileErrorReasonInternal    = "internal"
+	ReconcileErrorReasonConflict    = "conflict"
+	ReconcileErrorReasonFinalizer   = "finalizer"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "formance_operator",
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of the reconciliations",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind", "stack"})
+	reconcilePendingTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "formance_operator",
+		Name:      "reconcile_pending_total",
+		Help:      "Number of reconciliations terminated waiting for another object",
+	}, []string{"kind", "stack"})
+	reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "formance_operator",
+		Name:      "reconcile_errors_total",
+		Help:      "Number of reconciliations terminated with an error",
+	}, []string{"kind", "stack", "reason"})
+	objectReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "formance_operator",
+		Name:      "ready",
+		Help:      "Whether the object is ready (1) or not (0)",
+	}, []string{"kind", "stack", "name"})
+	objectLastReadyTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "formance_operator",
+		Name:      "last_ready_timestamp_seconds",
+		Help:      "Last time the object has been seen ready, as a unix timestamp",
+	}, []string{"kind", "stack", "name"})
+	driftDetectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "formance_operator",
+		Name:      "drift_detected_total",
+		Help:      "Number of objects managed by the operator detected as changed outside of the operator",
+	}, []string{"kind", "stack", "drifted_kind"})
+)
+
+func observeReconcile(kind, stack, name string, duration time.Duration, ready bool, err error) {
+	reconcileDuration.WithLabelValues(kind, stack).Observe(duration.Seconds())
+
+	switch {
+	case err == nil:
+	case IsPendingError(err):
+		reconcilePendingTotal.WithLabelValues(kind, stack).Inc()
+	default:
+		reconcileErrorsTotal.WithLabelValues(kind, stack, getErrorReason(err)).Inc()
+	}
+
+	if ready {
+		objectReady.WithLabelValues(kind, stack, name).Set(1)
+		objectLastReadyTimestamp.WithLabelValues(kind, stack, name).SetToCurrentTime()
+	} else {
+		objectReady.WithLabelValues(kind, stack, name).Set(0)
+	}
+}
+
+func observeDrifts(kind, stack string, drifts []Drift) {
+	for _, drift := range drifts {
+		driftDetectedTotal.WithLabelValues(kind, stack, drift.Kind).Inc()
+	}
+}
+
+func observeFinalizerError(kind, stack string) {
+	reconcileErrorsTotal.WithLabelValues(kind, stack, ReconcileErrorReasonFinalizer).Inc()
+}
+
+// forgetObjectMetrics removes the metrics of a deleted object
+func forgetObjectMetrics(kind, name string) {
+	labels := prometheus.Labels{
+		"kind": kind,
+		"name": name,
+	}
+	objectReady.DeletePartialMatch(labels)
+	objectLastReadyTimestamp.DeletePartialMatch(labels)
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileDuration,
+		reconcilePendingTotal,
+		reconcileErrorsTotal,
+		objectReady,
+		objectLastReadyTimestamp,
+		driftDetectedTotal,
+	)
+}
@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 type Platform struct {
 	// Cloud region where the stack is deployed
 	Region string
@@ -10,4 +12,10 @@ type Platform struct {
 	LicenceSecret string
 	// The operator utils image version
 	UtilsVersion string
+	// The maximum delay before reconciling again an object in error, zero means no limit
+	MaxRequeueDelay time.Duration
+	// The shard handled by this instance of the operator
+	Sharding Sharding
+	// The stacks handled by this instance of the operator
+	StackSelector StackSelector
 }
@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
 	"strings"
 	"time"
@@ -63,6 +64,10 @@ type ReconcilerOptions[T client.Object] struct {
 	Watchers   map[client.Object]ReconcilerOptionsWatch
 	Finalizers []finalizerConfig[T]
 	Raws       []func(Context, *builder.Builder) error
+	// RequeueAfter allow to periodically reconcile the object even if nothing changed
+	RequeueAfter time.Duration
+	// RequeuePolicies define when the object is reconciled again after an application error
+	RequeuePolicies map[RequeueErrorType]RequeuePolicy
 }
 
 type ReconcilerOption[T client.Object] func(*ReconcilerOptions[T])
@@ -79,6 +84,13 @@ func WithRaw[T client.Object](fn func(Context, *builder.Builder) error) Reconcil
 	}
 }
 
+// WithRequeueAfter allow to reconcile periodically objects whose state depends on external systems
+func WithRequeueAfter[T client.Object](d time.Duration) ReconcilerOption[T] {
+	return func(options *ReconcilerOptions[T]) {
+		options.RequeueAfter = d
+	}
+}
+
 func BuildReconcileRequests(ctx context.Context, client client.Client, scheme *runtime.Scheme, target client.Object, opts ...client.ListOption) []reconcile.Request {
 	kinds, _, err := scheme.ObjectKinds(target)
 	if err != nil {
@@ -148,6 +160,7 @@ func WithWatchStack[T client.Object]() ReconcilerOption[T] {
 					builder.WithPredicates(predicate.Or(
 						predicate.GenerationChangedPredicate{},
 						predicate.AnnotationChangedPredicate{},
+						predicate.LabelChangedPredicate{},
 					)),
 				}
 			},
@@ -173,43 +186,53 @@ func withReconciler[T client.Object](controller ObjectController[T], opts ...Rec
 	return func(mgr Manager) error {
 
 		options := ReconcilerOptions[T]{
-			Owns:     map[client.Object][]builder.OwnsOption{},
-			Watchers: map[client.Object]ReconcilerOptionsWatch{},
+			Owns:            map[client.Object][]builder.OwnsOption{},
+			Watchers:        map[client.Object]ReconcilerOptionsWatch{},
+			RequeuePolicies: maps.Clone(DefaultRequeuePolicies),
 		}
 		for _, opt := range opts {
 			opt(&options)
 		}
 
-		var t T
-		t = reflect.New(reflect.TypeOf(t).Elem()).Interface().(T)
-		b := ctrl.NewControllerManagedBy(mgr).
-			For(t, builder.WithPredicates(predicate.Or(
-				predicate.GenerationChangedPredicate{},
-				predicate.Funcs{
-					CreateFunc: func(event event.CreateEvent) bool {
-						return true
-					},
-					DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
-						return true
-					},
-					UpdateFunc: func(updateEvent event.UpdateEvent) bool {
-					l:
-						for _, referenceFromNew := range updateEvent.ObjectNew.GetOwnerReferences() {
-							for _, referenceFromOld := range updateEvent.ObjectOld.GetOwnerReferences() {
-								if referenceFromNew.UID == referenceFromOld.UID {
-									continue l
-								}
+		forPredicates := []predicate.Predicate{
+			predicate.GenerationChangedPredicate{},
+			predicate.Funcs{
+				CreateFunc: func(event event.CreateEvent) bool {
+					return true
+				},
+				DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+					return true
+				},
+				UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+				l:
+					for _, referenceFromNew := range updateEvent.ObjectNew.GetOwnerReferences() {
+						for _, referenceFromOld := range updateEvent.ObjectOld.GetOwnerReferences() {
+							if referenceFromNew.UID == referenceFromOld.UID {
+								continue l
 							}
-							return true
 						}
-
-						return len(updateEvent.ObjectOld.GetOwnerReferences()) != len(updateEvent.ObjectNew.GetOwnerReferences())
-					},
-					GenericFunc: func(genericEvent event.GenericEvent) bool {
 						return true
-					},
+					}
+
+					return len(updateEvent.ObjectOld.GetOwnerReferences()) != len(updateEvent.ObjectNew.GetOwnerReferences())
 				},
-			)))
+				GenericFunc: func(genericEvent event.GenericEvent) bool {
+					return true
+				},
+			},
+		}
+		if mgr.GetPlatform().Sharding.Enabled() || mgr.GetPlatform().StackSelector.Enabled() {
+			// Labels can pin a stack on a shard or select it
+			forPredicates = append(forPredicates, predicate.LabelChangedPredicate{})
+		}
+
+		var t T
+		t = reflect.New(reflect.TypeOf(t).Elem()).Interface().(T)
+		b := ctrl.NewControllerManagedBy(mgr).
+			For(t, builder.WithPredicates(
+				predicate.Or(forPredicates...),
+				managedPredicate(mgr),
+			))
 
 		for object, ownsOptions := range options.Owns {
 			b = b.Owns(object, ownsOptions...)
@@ -229,19 +252,32 @@ func withReconciler[T client.Object](controller ObjectController[T], opts ...Rec
 }
 
 func reconcileObject[T client.Object](mgr Manager, controller ObjectController[T], reconcilerOptions ReconcilerOptions[T]) func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	recorder := mgr.GetEventRecorderFor("formance-operator")
+	failures := newFailureCounter()
+
 	return func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 
 		var object T
 		object = reflect.New(reflect.TypeOf(object).Elem()).Interface().(T)
+		kind := reflect.TypeOf(object).Elem().Name()
 		if err := mgr.GetClient().Get(ctx, types.NamespacedName{
 			Name: request.Name,
 		}, object); err != nil {
 			if apierrors.IsNotFound(err) {
+				forgetObjectMetrics(kind, request.Name)
+				failures.reset(request.Name)
 				return ctrl.Result{}, nil
 			}
 			return ctrl.Result{}, err
 		}
 
+		// Requests triggered by watched objects are not filtered by the managed predicate
+		if managed, err := isManaged(ctx, mgr, object); err != nil {
+			return ctrl.Result{}, err
+		} else if !managed {
+			return ctrl.Result{}, nil
+		}
+
 		objectFinalizers := object.GetFinalizers()
 	l:
 		for _, existingFinalizer := range objectFinalizers {
@@ -271,13 +307,19 @@ func reconcileObject[T client.Object](mgr Manager, controller ObjectController[T
 					continue
 				}
 
+				previous := object.DeepCopyObject().(T)
 				if err := f.fn(reconcileContext, object); err != nil {
+					observeFinalizerError(kind, getObjectStack(object))
+					recordFinalizerEvent(recorder, previous, object, f.name, err)
 					if IsApplicationError(err) {
 						log.FromContext(ctx).Info(fmt.Sprintf("Finalizer respond with error: %s", err))
 						if setError, ok := any(object).(interface {
 							SetError(string)
 						}); ok {
 							setError.SetError(err.Error())
+							if object, ok := any(object).(v1beta1.Object); ok {
+								setStandardConditions(object, err)
+							}
 							if err := mgr.GetClient().Status().Update(ctx, object); err != nil {
 								log.FromContext(ctx).Info(fmt.Sprintf("Catching error: %s", err))
 								return reconcile.Result{}, errors.Wrapf(err, "patching resource to remove finalizer '%s'", f.name)
@@ -285,7 +327,12 @@ func reconcileObject[T client.Object](mgr Manager, controller ObjectController[T
 						}
 
 						return reconcile.Result{
-							RequeueAfter: time.Second,
+							RequeueAfter: getRequeueDelay(
+								mgr.GetPlatform(),
+								reconcilerOptions.RequeuePolicies[RequeueFinalizer],
+								failures.inc(request.Name, RequeueFinalizer),
+								err,
+							),
 						}, nil
 					}
 					return reconcile.Result{}, errors.Wrapf(err, "executing finalizer '%s'", f.name)
@@ -331,7 +378,17 @@ func reconcileObject[T client.Object](mgr Manager, controller ObjectController[T
 		patch := client.MergeFrom(cp)
 
 		var reconcilerError error
+		startedAt := time.Now()
 		err := controller(reconcileContext, &reconcilerOptions, object)
+		observeReconcile(kind, getObjectStack(object), object.GetName(), time.Since(startedAt), isObjectReady(object), err)
+		recordReconcileEvent(recorder, cp, object, err)
+		drifts := reconcileContext.getDrifts()
+		// The ignored drifts already reported are only kept on the condition
+		observeDrifts(kind, getObjectStack(object), newDrifts(drifts))
+		recordDriftEvents(recorder, object, newDrifts(drifts))
+		if object, ok := any(object).(v1beta1.Object); ok {
+			setDriftCondition(object, drifts, err)
+		}
 		if err != nil {
 			log.FromContext(ctx).Info(fmt.Sprintf("Terminated with error: %s", err))
 			if !IsApplicationError(err) {
@@ -353,7 +410,31 @@ func reconcileObject[T client.Object](mgr Manager, controller ObjectController[T
 			}, nil
 		}
 
-		return ctrl.Result{}, reconcilerError
+		if reconcilerError != nil {
+			// Internal errors are retried using the rate limiter of the controller
+			failures.reset(request.Name)
+			return ctrl.Result{}, reconcilerError
+		}
+
+		requeueAfter := reconcilerOptions.RequeueAfter
+		if err != nil {
+			errorType := RequeueApplication
+			if IsPendingError(err) {
+				errorType = RequeuePending
+			}
+			requeueAfter = minRequeueDelay(requeueAfter, getRequeueDelay(
+				mgr.GetPlatform(),
+				reconcilerOptions.RequeuePolicies[errorType],
+				failures.inc(request.Name, errorType),
+				err,
+			))
+		} else {
+			failures.reset(request.Name)
+		}
+
+		return ctrl.Result{
+			RequeueAfter: requeueAfter,
+		}, nil
 	}
 }
 
@@ -400,6 +481,18 @@ func WithModuleReconciler[T v1beta1.Module](fn func(ctx Context, stack *v1beta1.
 		opts...)
 }
 
+// WithUnversionedModuleReconciler registers the reconciler of a module not released with the stack
+func WithUnversionedModuleReconciler[T v1beta1.Module](fn func(ctx Context, stack *v1beta1.Stack, req T) error, opts ...ReconcilerOption[T]) Initializer {
+	return withStackDependencyReconciler(
+		ForStackDependency(
+			ForUnversionedModule(func(ctx Context, stack *v1beta1.Stack, reconcilerOptions *ReconcilerOptions[T], req T, _ string) error {
+				return fn(ctx, stack, req)
+			}),
+			false,
+		),
+		opts...)
+}
+
 func WithWatchVersions[T client.Object](options *ReconcilerOptions[T]) {
 
 	reconcileModule := func(ctx context.Context, mgr Manager, target client.Object, versionFileName string, limitingInterface workqueue.TypedRateLimitingInterface[reconcile.Request]) {
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type RequeueErrorType string
+
+const (
+	// RequeuePending applies to the reconciliations terminated with a pending error
+	RequeuePending RequeueErrorType = "pending"
+	// RequeueApplication applies to the reconciliations terminated with an application error
+	RequeueApplication RequeueErrorType = "application"
+	// RequeueFinalizer applies to the finalizers terminated with an application error
+	RequeueFinalizer RequeueErrorType = "finalizer"
+)
+
+// RequeuePolicy defines when an object is reconciled again after a reconciliation terminated with an error.
+// The delay grows exponentially with the number of consecutive failures of the same type.
+type RequeuePolicy struct {
+	// InitialDelay is the delay before the first retry.
+	// A zero delay disables the retry: the object is only reconciled again on watch events.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay, before jitter
+	MaxDelay time.Duration
+	// Factor multiplies the delay after each failure
+	Factor float64
+	// Jitter adds a random delay up to the given fraction of the delay,
+	// to spread the reconciliations of objects failing at the same time
+	Jitter float64
+}
+
+// Delay returns the delay before the next reconciliation after the given number of consecutive failures
+func (p RequeuePolicy) Delay(failures int) time.Duration {
+	if p.InitialDelay <= 0 {
+		return 0
+	}
+
+	delay := float64(p.InitialDelay)
+	for i := 1; i < failures && p.Factor > 1; i++ {
+		delay *= p.Factor
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+// DefaultRequeuePolicies are the policies used by the reconcilers unless overridden with WithRequeuePolicy.
+// Objects waiting for a watched object should return an error with OnlyOnWatchEvent, to avoid useless reconciliations.
+var DefaultRequeuePolicies = map[RequeueErrorType]RequeuePolicy{
+	RequeuePending: {
+		InitialDelay: 5 * time.Second,
+		MaxDelay:     2 * time.Minute,
+		Factor:       2,
+		Jitter:       0.1,
+	},
+	RequeueApplication: {
+		InitialDelay: 10 * time.Second,
+		MaxDelay:     5 * time.Minute,
+		Factor:       2,
+		Jitter:       0.1,
+	},
+	RequeueFinalizer: {
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Factor:       2,
+		Jitter:       0.1,
+	},
+}
+
+// WithRequeuePolicy overrides the requeue policy of the reconciler for a type of error
+func WithRequeuePolicy[T client.Object](errorType RequeueErrorType, policy RequeuePolicy) ReconcilerOption[T] {
+	return func(options *ReconcilerOptions[T]) {
+		options.RequeuePolicies[errorType] = policy
+	}
+}
+
+// getRequeueDelay returns the delay before the next reconciliation of an object after an application error.
+// The hint carried by the error has priority over the policy.
+// The delay is capped by the maximum delay configured on the platform.
+func getRequeueDelay(platform Platform, policy RequeuePolicy, failures int, err error) time.Duration {
+	delay := policy.Delay(failures)
+	if requeueAfter, ok := getRequeueHint(err); ok {
+		delay = requeueAfter
+	}
+	if platform.MaxRequeueDelay > 0 && delay > platform.MaxRequeueDelay {
+		delay = platform.MaxRequeueDelay
+	}
+
+	return delay
+}
+
+// minRequeueDelay returns the smallest non zero delay
+func minRequeueDelay(a, b time.Duration) time.Duration {
+	switch {
+	case a == 0:
+		return b
+	case b == 0:
+		return a
+	case a < b:
+		return a
+	default:
+		return b
+	}
+}
+
+type failure struct {
+	errorType RequeueErrorType
+	count     int
+}
+
+// failureCounter counts the consecutive failures of the objects of a reconciler
+type failureCounter struct {
+	mu       sync.Mutex
+	failures map[string]failure
+}
+
+// inc records a failure of the object and returns the number of consecutive failures with the same error type
+func (c *failureCounter) inc(name string, errorType RequeueErrorType) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f := c.failures[name]
+	if f.errorType != errorType {
+		f = failure{
+			errorType: errorType,
+		}
+	}
+	f.count++
+	c.failures[name] = f
+
+	return f.count
+}
+
+func (c *failureCounter) reset(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.failures, name)
+}
+
+func newFailureCounter() *failureCounter {
+	return &failureCounter{
+		failures: map[string]failure{},
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequeuePolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := RequeuePolicy{
+		InitialDelay: time.Second,
+		MaxDelay:     10 * time.Second,
+		Factor:       2,
+	}
+
+	require.Equal(t, time.Second, policy.Delay(1))
+	require.Equal(t, 2*time.Second, policy.Delay(2))
+	require.Equal(t, 8*time.Second, policy.Delay(4))
+	require.Equal(t, 10*time.Second, policy.Delay(5))
+	require.Equal(t, 10*time.Second, policy.Delay(1000))
+
+	require.Zero(t, RequeuePolicy{}.Delay(3))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.Delay(2)
+		require.GreaterOrEqual(t, delay, 2*time.Second)
+		require.LessOrEqual(t, delay, 3*time.Second)
+	}
+}
+
+func TestGetRequeueDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := RequeuePolicy{
+		InitialDelay: time.Second,
+		MaxDelay:     time.Hour,
+		Factor:       10,
+	}
+
+	require.Equal(t, 100*time.Second, getRequeueDelay(Platform{}, policy, 3, NewPendingError()))
+	require.Equal(t, 30*time.Second, getRequeueDelay(Platform{}, policy, 3, NewPendingError().WithRequeueAfter(30*time.Second)))
+	require.Zero(t, getRequeueDelay(Platform{}, policy, 3, NewPendingError().OnlyOnWatchEvent()))
+	require.Equal(t, time.Minute, getRequeueDelay(Platform{MaxRequeueDelay: time.Minute}, policy, 3, NewPendingError()))
+	require.Equal(t, time.Minute, getRequeueDelay(Platform{MaxRequeueDelay: time.Minute}, policy, 1,
+		NewPendingError().WithRequeueAfter(time.Hour)))
+	require.Equal(t, 10*time.Second, getRequeueDelay(Platform{}, policy, 2, errors.New("error")))
+}
+
+func TestFailureCounter(t *testing.T) {
+	t.Parallel()
+
+	counter := newFailureCounter()
+	require.Equal(t, 1, counter.inc("a", RequeuePending))
+	require.Equal(t, 2, counter.inc("a", RequeuePending))
+	require.Equal(t, 1, counter.inc("b", RequeuePending))
+	require.Equal(t, 1, counter.inc("a", RequeueApplication))
+	counter.reset("a")
+	require.Equal(t, 1, counter.inc("a", RequeueApplication))
+}
+
+func TestDefaultRequeuePolicies(t *testing.T) {
+	t.Parallel()
+
+	for _, errorType := range []RequeueErrorType{RequeuePending, RequeueApplication, RequeueFinalizer} {
+		policy := DefaultRequeuePolicies[errorType]
+		require.NotZero(t, policy.Delay(1), errorType)
+		require.Greater(t, policy.Delay(3), policy.InitialDelay, errorType)
+		require.LessOrEqual(t, policy.Delay(1000), time.Duration(float64(policy.MaxDelay)*(1+policy.Jitter)), errorType)
+	}
+
+	// Objects waiting for a watched object are not retried
+	require.Zero(t, getRequeueDelay(Platform{}, DefaultRequeuePolicies[RequeuePending], 1, NewPendingError().OnlyOnWatchEvent()))
+}
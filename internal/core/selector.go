@@ -0,0 +1,97 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+// StackSelector restricts the stacks handled by an instance of the operator.
+// It allows several operators, possibly using different versions, to share a cluster.
+// Objects not related to a stack are ignored when a selector is configured.
+type StackSelector struct {
+	// Labels selects the stacks by labels, nil selects all stacks
+	Labels labels.Selector
+	// NamePrefix selects the stacks with a name starting with the prefix
+	NamePrefix string
+}
+
+func (s StackSelector) Enabled() bool {
+	return (s.Labels != nil && !s.Labels.Empty()) || s.NamePrefix != ""
+}
+
+// Matches returns whether the stack is handled by this instance
+func (s StackSelector) Matches(stack *v1beta1.Stack) bool {
+	if !strings.HasPrefix(stack.Name, s.NamePrefix) {
+		return false
+	}
+	if s.Labels != nil && !s.Labels.Matches(labels.Set(stack.Labels)) {
+		return false
+	}
+	return true
+}
+
+// Selects returns whether the object belongs to a stack handled by this instance
+func (s StackSelector) Selects(ctx context.Context, reader client.Reader, object client.Object) (bool, error) {
+	if !s.Enabled() {
+		return true, nil
+	}
+
+	stack, found, err := getReferencedStack(ctx, reader, object)
+	if err != nil {
+		return false, err
+	}
+	if stack == nil {
+		return false, nil
+	}
+	// The stacks not matching the label selector are not loaded in the cache,
+	// so the labels of a stack not found are unknown, and a negative selector would match them
+	if !found && s.Labels != nil && !s.Labels.Empty() {
+		return false, nil
+	}
+
+	return s.Matches(stack), nil
+}
+
+// isManaged returns whether the object must be reconciled by this instance of the operator
+func isManaged(ctx context.Context, mgr Manager, object client.Object) (bool, error) {
+	selected, err := mgr.GetPlatform().StackSelector.Selects(ctx, mgr.GetClient(), object)
+	if err != nil || !selected {
+		return false, err
+	}
+
+	return mgr.GetPlatform().Sharding.Owns(ctx, mgr.GetClient(), object)
+}
+
+// managedPredicate filters the events of the objects handled by other instances of the operator
+func managedPredicate(mgr Manager) predicate.Predicate {
+	managed := func(object client.Object) bool {
+		ok, err := isManaged(context.Background(), mgr, object)
+		if err != nil {
+			// Let the reconciler handle the error
+			return true
+		}
+		return ok
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return managed(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return managed(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return managed(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return managed(e.Object)
+		},
+	}
+}
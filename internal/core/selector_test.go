@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestStackSelector(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	newStack := func(name, tenant string) *v1beta1.Stack {
+		return &v1beta1.Stack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"tenant": tenant,
+				},
+			},
+		}
+	}
+	newLedger := func(stack string) *v1beta1.Ledger {
+		return &v1beta1.Ledger{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: stack + "-ledger",
+			},
+			Spec: v1beta1.LedgerSpec{
+				StackDependency: v1beta1.StackDependency{
+					Stack: stack,
+				},
+			},
+		}
+	}
+	newClone := func(destination string) *v1beta1.StackClone {
+		return &v1beta1.StackClone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "clone",
+			},
+			Spec: v1beta1.StackCloneSpec{
+				Source:      "acme-source",
+				Destination: destination,
+			},
+		}
+	}
+
+	reader := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newStack("acme-dev", "acme"),
+			newStack("acme-prod", "other"),
+			newStack("other-dev", "acme"),
+		).
+		Build()
+
+	selector := StackSelector{
+		Labels:     labels.SelectorFromSet(labels.Set{"tenant": "acme"}),
+		NamePrefix: "acme-",
+	}
+	require.True(t, selector.Enabled())
+	require.False(t, StackSelector{Labels: labels.Everything()}.Enabled())
+
+	type testCase struct {
+		object   client.Object
+		expected bool
+	}
+	for _, tc := range []testCase{
+		{object: newStack("acme-dev", "acme"), expected: true},
+		{object: newStack("acme-prod", "other")},
+		{object: newStack("other-dev", "acme")},
+		{object: newLedger("acme-dev"), expected: true},
+		{object: newLedger("acme-prod")},
+		{object: newLedger("missing")},
+		{object: newClone("acme-dev"), expected: true},
+		{object: newClone("other-dev")},
+		{object: &v1beta1.Settings{}},
+	} {
+		selected, err := selector.Selects(context.Background(), reader, tc.object)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, selected, tc.object.GetName())
+
+		selected, err = StackSelector{}.Selects(context.Background(), reader, tc.object)
+		require.NoError(t, err)
+		require.True(t, selected)
+	}
+}
+
+func TestStackSelectorNegative(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	newLedger := func(stack string) *v1beta1.Ledger {
+		return &v1beta1.Ledger{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: stack + "-ledger",
+			},
+			Spec: v1beta1.LedgerSpec{
+				StackDependency: v1beta1.StackDependency{
+					Stack: stack,
+				},
+			},
+		}
+	}
+
+	selector, err := labels.Parse("tenant!=a")
+	require.NoError(t, err)
+
+	// As the cache of the operator, the reader only contains the stacks matching the selector
+	reader := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&v1beta1.Stack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "selected",
+				Labels: map[string]string{
+					"tenant": "b",
+				},
+			},
+		}).
+		Build()
+
+	selected, err := StackSelector{Labels: selector}.Selects(context.Background(), reader, newLedger("selected"))
+	require.NoError(t, err)
+	require.True(t, selected)
+
+	// The stack of the tenant a is not in the cache
+	selected, err = StackSelector{Labels: selector}.Selects(context.Background(), reader, newLedger("excluded"))
+	require.NoError(t, err)
+	require.False(t, selected)
+
+	// Without label selector, the stacks are all cached, and the name prefix can be applied on missing ones
+	selected, err = StackSelector{NamePrefix: "excluded"}.Selects(context.Background(), reader, newLedger("excluded"))
+	require.NoError(t, err)
+	require.True(t, selected)
+}
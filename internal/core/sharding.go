@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+// Sharding splits the stacks between several instances of the operator.
+// Each instance reconciles the stacks of its shard, and the objects depending on them.
+// Objects not related to a stack are reconciled by the first shard.
+type Sharding struct {
+	// Shards is the total number of shards, sharding is disabled when lower than 2
+	Shards int
+	// Shard is the index of the shard handled by this instance, from 0 to Shards-1
+	Shard int
+}
+
+func (s Sharding) Enabled() bool {
+	return s.Shards > 1
+}
+
+// GetStackShard returns the shard of a stack.
+// The label formance.com/shard allow to pin a stack on a shard, otherwise a consistent hash of the name
+// of the stack is used, so only a few stacks move when the number of shards changes.
+func (s Sharding) GetStackShard(name string, labels map[string]string) int {
+	if value, ok := labels[v1beta1.ShardLabel]; ok {
+		if shard, err := strconv.Atoi(value); err == nil && shard >= 0 && shard < s.Shards {
+			return shard
+		}
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	return jumpHash(h.Sum64(), s.Shards)
+}
+
+// Owns returns whether the object must be reconciled by this instance
+func (s Sharding) Owns(ctx context.Context, reader client.Reader, object client.Object) (bool, error) {
+	if !s.Enabled() {
+		return true, nil
+	}
+
+	stack, _, err := getReferencedStack(ctx, reader, object)
+	if err != nil {
+		return false, err
+	}
+	if stack == nil {
+		return s.Shard == 0, nil
+	}
+
+	return s.GetStackShard(stack.Name, stack.Labels) == s.Shard, nil
+}
+
+// getReferencedStack returns the stack an object belongs to, or nil for objects not related to a stack.
+// When the stack does not exist, the returned stack only has a name, and found is false.
+func getReferencedStack(ctx context.Context, reader client.Reader, object client.Object) (stack *v1beta1.Stack, found bool, err error) {
+	var name string
+	switch object := object.(type) {
+	case *v1beta1.Stack:
+		return object, true, nil
+	case *v1beta1.StackClone:
+		name = object.Spec.Destination
+	case v1beta1.Dependent:
+		name = object.GetStack()
+	}
+	if name == "" {
+		return nil, false, nil
+	}
+
+	stack = &v1beta1.Stack{}
+	if err := reader.Get(ctx, types.NamespacedName{
+		Name: name,
+	}, stack); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, false, err
+		}
+		stack.Name = name
+		return stack, false, nil
+	}
+
+	return stack, true, nil
+}
+
+// jumpHash is the jump consistent hash algorithm from Lamping and Veach
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestGetStackShard(t *testing.T) {
+	t.Parallel()
+
+	sharding := Sharding{Shards: 4}
+
+	counts := make([]int, sharding.Shards)
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("stack-%d", i)
+		shard := sharding.GetStackShard(name, nil)
+		require.Equal(t, shard, sharding.GetStackShard(name, nil))
+		counts[shard]++
+	}
+	for _, count := range counts {
+		require.InDelta(t, 250, count, 60)
+	}
+
+	// Adding a shard only moves stacks to the new shard
+	more := Sharding{Shards: 5}
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("stack-%d", i)
+		if shard := more.GetStackShard(name, nil); shard != 4 {
+			require.Equal(t, sharding.GetStackShard(name, nil), shard)
+		}
+	}
+
+	require.Equal(t, 3, sharding.GetStackShard("stack-0", map[string]string{
+		v1beta1.ShardLabel: "3",
+	}))
+	require.Equal(t, sharding.GetStackShard("stack-0", nil), sharding.GetStackShard("stack-0", map[string]string{
+		v1beta1.ShardLabel: "10",
+	}))
+}
+
+func TestShardingOwns(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	stack := &v1beta1.Stack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "stack0",
+			Labels: map[string]string{
+				v1beta1.ShardLabel: "1",
+			},
+		},
+	}
+	ledger := &v1beta1.Ledger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "stack0-ledger",
+		},
+		Spec: v1beta1.LedgerSpec{
+			StackDependency: v1beta1.StackDependency{
+				Stack: "stack0",
+			},
+		},
+	}
+	settings := &v1beta1.Settings{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "settings",
+		},
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stack).Build()
+
+	for shard, expected := range []bool{false, true} {
+		sharding := Sharding{Shards: 2, Shard: shard}
+
+		owned, err := sharding.Owns(context.Background(), reader, stack)
+		require.NoError(t, err)
+		require.Equal(t, expected, owned)
+
+		owned, err = sharding.Owns(context.Background(), reader, ledger)
+		require.NoError(t, err)
+		require.Equal(t, expected, owned)
+
+		owned, err = sharding.Owns(context.Background(), reader, settings)
+		require.NoError(t, err)
+		require.Equal(t, shard == 0, owned)
+	}
+
+	owned, err := Sharding{}.Owns(context.Background(), reader, stack)
+	require.NoError(t, err)
+	require.True(t, owned)
+}
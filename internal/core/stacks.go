@@ -6,7 +6,12 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 )
 
 var (
@@ -89,3 +94,54 @@ func GetIfExists(ctx Context, stackName string, to client.Object) (bool, error)
 	}
 	return true, nil
 }
+
+// GetModuleOfDependent retrieves the module of the stack managing an object created on its API, like a payments connector.
+// The object is also made owned by the stack, so it is removed with it.
+func GetModuleOfDependent(ctx Context, stack *v1beta1.Stack, object client.Object, module v1beta1.Module) error {
+	found, err := GetIfExists(ctx, stack.Name, module)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return NewApplicationError().WithMessage("%s module not found on stack %s", LowerCaseKind(ctx, module), stack.Name)
+	}
+
+	hasOwnerReference, err := HasOwnerReference(ctx, stack, object)
+	if err != nil {
+		return err
+	}
+	if hasOwnerReference {
+		return nil
+	}
+
+	patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+	if err := controllerutil.SetOwnerReference(stack, object, ctx.GetScheme()); err != nil {
+		return err
+	}
+
+	return ctx.GetClient().Patch(ctx, object, patch)
+}
+
+// GetModuleForCleanup retrieves the stack and the module managing an object created on the API of the module,
+// when the object is deleted.
+// It returns false if the stack or the module is missing or being deleted, the objects created on the API
+// are then removed with the module.
+func GetModuleForCleanup(ctx Context, stackName string, module v1beta1.Module) (*v1beta1.Stack, bool, error) {
+	stack := &v1beta1.Stack{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: stackName,
+	}, stack); err != nil {
+		return nil, false, client.IgnoreNotFound(err)
+	}
+
+	found, err := GetIfExists(ctx, stack.Name, module)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found || !module.GetDeletionTimestamp().IsZero() || !stack.GetDeletionTimestamp().IsZero() {
+		log.FromContext(ctx).Info("Module is not available, skip cleanup", "module", LowerCaseKind(ctx, module))
+		return nil, false, nil
+	}
+
+	return stack, true, nil
+}
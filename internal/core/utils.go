@@ -130,11 +130,35 @@ func CreateOrUpdate[T client.Object](ctx Context,
 	ret.SetNamespace(key.Namespace)
 	ret.SetName(key.Name)
 	operationResult, err := controllerutil.CreateOrUpdate(ctx, ctx.GetClient(), ret, func() error {
+		var existing T
+		if ret.GetResourceVersion() != "" {
+			existing = ret.DeepCopyObject().(T)
+		}
 		for _, mutate := range mutators {
 			if err := mutate(ret); err != nil {
 				return err
 			}
 		}
+
+		var existingObject client.Object
+		if ret.GetResourceVersion() != "" {
+			existingObject = existing
+		}
+		drift, err := detectDrift(ctx.GetScheme(), existingObject, ret)
+		if err != nil {
+			return err
+		}
+		if drift != nil {
+			if reporter, ok := ctx.(driftReporter); ok {
+				reporter.reportDrift(*drift)
+			}
+			if drift.Ignored {
+				// Keep the manual changes, only the drift is recorded on the object
+				reflect.ValueOf(ret).Elem().Set(reflect.ValueOf(existing).Elem())
+				markIgnoredDrift(ret, *drift)
+			}
+		}
+
 		return nil
 	})
 	return ret, operationResult, err
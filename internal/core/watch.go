@@ -13,6 +13,10 @@ import (
 func WatchDependents(mgr Manager, t client.Object) func(ctx context.Context, object client.Object) []reconcile.Request {
 	return func(ctx context.Context, object client.Object) []reconcile.Request {
 
+		if selected, err := mgr.GetPlatform().StackSelector.Selects(ctx, mgr.GetClient(), object); err != nil || !selected {
+			return nil
+		}
+
 		slice := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(t)), 0, 0).Interface()
 
 		err := GetAllStackDependencies(
@@ -34,6 +38,10 @@ func WatchDependents(mgr Manager, t client.Object) func(ctx context.Context, obj
 func Watch(mgr Manager, t client.Object) func(ctx context.Context, object client.Object) []reconcile.Request {
 	return func(ctx context.Context, object client.Object) []reconcile.Request {
 
+		if selector := mgr.GetPlatform().StackSelector; selector.Enabled() && !selector.Matches(object.(*v1beta1.Stack)) {
+			return nil
+		}
+
 		slice := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(t)), 0, 0).Interface()
 
 		err := GetAllStackDependencies(
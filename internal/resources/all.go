@@ -7,6 +7,7 @@ import (
 	_ "github.com/formancehq/operator/v3/internal/resources/benthosstreams"
 	_ "github.com/formancehq/operator/v3/internal/resources/brokers"
 	_ "github.com/formancehq/operator/v3/internal/resources/brokertopics"
+	_ "github.com/formancehq/operator/v3/internal/resources/custommodules"
 	_ "github.com/formancehq/operator/v3/internal/resources/databases"
 	_ "github.com/formancehq/operator/v3/internal/resources/gatewayhttpapis"
 	_ "github.com/formancehq/operator/v3/internal/resources/gateways"
@@ -17,6 +18,7 @@ import (
 	_ "github.com/formancehq/operator/v3/internal/resources/resourcereferences"
 	_ "github.com/formancehq/operator/v3/internal/resources/searches"
 	_ "github.com/formancehq/operator/v3/internal/resources/settings"
+	_ "github.com/formancehq/operator/v3/internal/resources/stackclones"
 	_ "github.com/formancehq/operator/v3/internal/resources/stacks"
 	_ "github.com/formancehq/operator/v3/internal/resources/stargates"
 	_ "github.com/formancehq/operator/v3/internal/resources/transactionplane"
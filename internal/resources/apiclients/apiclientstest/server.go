@@ -0,0 +1,43 @@
+// Package apiclientstest serves fake module APIs for the unit tests of the reconcilers using an apiclients.Client.
+package apiclientstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+)
+
+// NewClient serves the fake API of a module on a test server, closed at the end of the test,
+// and returns a client targeting it.
+// The requests are serialized, so the fake API can update its state without locking.
+func NewClient(t *testing.T, api http.Handler) *apiclients.Client {
+	t.Helper()
+
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return apiclients.NewClient(srv.URL, srv.Client())
+}
+
+// WriteJSON writes a JSON response of a fake API
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// PathSegments returns the segments of the path of a request to a fake API
+func PathSegments(r *http.Request) []string {
+	return strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+}
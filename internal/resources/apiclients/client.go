@@ -0,0 +1,168 @@
+package apiclients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/authclients"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Error is returned when a module API respond with an unexpected status code
+type Error struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client allow the operator to talk to the API of a module deployed on a stack.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// New creates a client targeting the API of the module.
+// Requests are sent through the gateway of the stack if any, otherwise directly to the module service.
+// When the stack has an Auth module, an AuthClient owned by the module is created for the operator and used to
+// obtain tokens with the given scopes.
+func New(ctx core.Context, stack *v1beta1.Stack, module v1beta1.Module, scopes ...string) (*Client, error) {
+	if !module.IsReady() {
+		return nil, core.NewPendingError().WithMessage("module %s not ready", module.GetName())
+	}
+
+	name := core.LowerCaseKind(ctx, module)
+
+	hasGateway, err := core.HasDependency(ctx, stack.Name, &v1beta1.Gateway{})
+	if err != nil {
+		return nil, err
+	}
+
+	root := fmt.Sprintf("http://%s.%s.svc.cluster.local:8080", name, stack.Name)
+	authURL := fmt.Sprintf("http://auth.%s.svc.cluster.local:8080", stack.Name)
+	if hasGateway {
+		gatewayURL := fmt.Sprintf("http://gateway.%s.svc.cluster.local:8080", stack.Name)
+		root = fmt.Sprintf("%s/api/%s", gatewayURL, name)
+		authURL = fmt.Sprintf("%s/api/auth", gatewayURL)
+	}
+
+	hasAuth, err := core.HasDependency(ctx, stack.Name, &v1beta1.Auth{})
+	if err != nil {
+		return nil, err
+	}
+	if !hasAuth {
+		return NewClient(root, &http.Client{
+			Timeout: defaultTimeout,
+		}), nil
+	}
+
+	authClient, err := authclients.Create(ctx, stack, module, fmt.Sprintf("%s-operator", name),
+		authclients.WithScopes(scopes...))
+	if err != nil {
+		return nil, err
+	}
+	if !authClient.Status.Ready {
+		return nil, core.NewPendingError().WithMessage("waiting for operator auth client to be ready")
+	}
+
+	clientSecret, err := authclients.GetSecret(ctx, stack, authClient)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := clientcredentials.Config{
+		ClientID:     authClient.Spec.ID,
+		ClientSecret: clientSecret,
+		TokenURL:     authURL + "/oauth/token",
+		Scopes:       scopes,
+	}
+
+	return NewClient(root, credentials.Client(context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+		Timeout: defaultTimeout,
+	}))), nil
+}
+
+// NewClient creates a client targeting the given url using a preconfigured http client
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Do sends a request to the module API.
+// If body is not nil, it is marshalled as json, if ret is not nil, the response is unmarshalled into it.
+func (c *Client) Do(ctx context.Context, method, path string, body, ret any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rsp.Body.Close()
+	}()
+
+	if rsp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return &Error{
+			StatusCode: rsp.StatusCode,
+			Body:       string(data),
+		}
+	}
+
+	if ret == nil || rsp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(rsp.Body).Decode(ret)
+}
+
+// Cursor is the standard envelope used by formance APIs to list objects
+type Cursor[T any] struct {
+	Cursor struct {
+		Data    []T    `json:"data"`
+		HasMore bool   `json:"hasMore"`
+		Next    string `json:"next,omitempty"`
+	} `json:"cursor"`
+}
+
+// Data is the standard envelope used by formance APIs to return a single object
+type Data[T any] struct {
+	Data T `json:"data"`
+}
@@ -320,6 +320,29 @@ func (a Application) withStatefulHandling(ctx core.Context) core.ObjectMutator[*
 	}
 }
 
+// withHibernation scales the deployment to zero when the stack is hibernated.
+// When the stack is woken up, the replicas are restored by withStatefulHandling for stateless applications,
+// stateful applications are restored to a single replica.
+func (a Application) withHibernation(ctx core.Context) core.ObjectMutator[*appsv1.Deployment] {
+	return func(deployment *appsv1.Deployment) error {
+		stack := &v1beta1.Stack{}
+		if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+			Name: a.owner.GetStack(),
+		}, stack); err != nil {
+			return err
+		}
+
+		switch {
+		case stack.Spec.Hibernated:
+			deployment.Spec.Replicas = pointer.For(int32(0))
+		case a.stateful && deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0:
+			deployment.Spec.Replicas = pointer.For(int32(1))
+		}
+
+		return nil
+	}
+}
+
 func (a Application) withEELicence(ctx core.Context) core.ObjectMutator[*appsv1.Deployment] {
 	return func(deployment *appsv1.Deployment) error {
 		if a.isEE {
@@ -498,6 +521,7 @@ func (a Application) handleDeployment(ctx core.Context, deploymentLabels map[str
 		a.containersMutator(ctx, deploymentLabels),
 		a.withSettingAnnotations(ctx),
 		a.withStatefulHandling(ctx),
+		a.withHibernation(ctx),
 		a.withEELicence(ctx),
 		a.withTopologySpreadConstraints(ctx),
 		a.withJsonLogging(ctx),
@@ -14,6 +14,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+
 	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	"github.com/formancehq/operator/v3/internal/core"
 	"github.com/formancehq/operator/v3/internal/resources/settings"
@@ -927,3 +929,91 @@ func TestWithSemconvMetricsNames(t *testing.T) {
 		})
 	}
 }
+
+func TestWithHibernation(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	type testCase struct {
+		name             string
+		hibernated       bool
+		stateful         bool
+		replicas         *int32
+		expectedReplicas *int32
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:             "hibernated",
+			hibernated:       true,
+			expectedReplicas: pointer.For(int32(0)),
+		},
+		{
+			name:             "hibernated stateful",
+			hibernated:       true,
+			stateful:         true,
+			replicas:         pointer.For(int32(1)),
+			expectedReplicas: pointer.For(int32(0)),
+		},
+		{
+			name:             "awake",
+			replicas:         pointer.For(int32(3)),
+			expectedReplicas: pointer.For(int32(3)),
+		},
+		{
+			name:             "awake stateful previously hibernated",
+			stateful:         true,
+			replicas:         pointer.For(int32(0)),
+			expectedReplicas: pointer.For(int32(1)),
+		},
+		{
+			name:             "awake stateful",
+			stateful:         true,
+			replicas:         pointer.For(int32(1)),
+			expectedReplicas: pointer.For(int32(1)),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stack := &v1beta1.Stack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-stack",
+				},
+				Spec: v1beta1.StackSpec{
+					Hibernated: tc.hibernated,
+				},
+			}
+
+			mockCtx := &mockContext{
+				Context: context.Background(),
+				client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(stack).Build(),
+				scheme:  scheme,
+			}
+
+			app := Application{
+				stateful: tc.stateful,
+				owner: &v1beta1.Ledger{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-ledger",
+					},
+					Spec: v1beta1.LedgerSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				},
+			}
+
+			deployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: tc.replicas,
+				},
+			}
+			require.NoError(t, app.withHibernation(mockCtx)(deployment))
+			require.Equal(t, tc.expectedReplicas, deployment.Spec.Replicas)
+		})
+	}
+}
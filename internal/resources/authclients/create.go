@@ -1,8 +1,6 @@
 package authclients
 
 import (
-	"fmt"
-
 	"github.com/google/uuid"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -46,7 +44,7 @@ func WithScopes(scopes ...string) func(client *v1beta1.AuthClientSpec) {
 
 func GetEnvVars(authClient *v1beta1.AuthClient) []v1.EnvVar {
 	return []v1.EnvVar{
-		core.EnvFromSecret("STACK_CLIENT_ID", fmt.Sprintf("auth-client-%s", authClient.Name), "id"),
-		core.EnvFromSecret("STACK_CLIENT_SECRET", fmt.Sprintf("auth-client-%s", authClient.Name), "secret"),
+		core.EnvFromSecret("STACK_CLIENT_ID", GetSecretName(authClient), "id"),
+		core.EnvFromSecret("STACK_CLIENT_SECRET", GetSecretName(authClient), SecretKey),
 	}
 }
@@ -18,6 +18,7 @@ package authclients
 
 import (
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -35,6 +36,9 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, authClient *v1beta1.AuthClient
 	if authClient.Spec.SecretFromSecret != nil && authClient.Spec.Secret != "" {
 		return fmt.Errorf("cannot specify signing key using both .spec.SecretFromSecret and .spec.Secret fields")
 	}
+	if authClient.Spec.SecretFromSecret != nil && authClient.Spec.Rotation != nil {
+		return fmt.Errorf("cannot enable rotation when using .spec.SecretFromSecret field")
+	}
 
 	resourceRefName := "client-secret"
 	if authClient.Spec.SecretFromSecret != nil {
@@ -50,14 +54,34 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, authClient *v1beta1.AuthClient
 		authClient.Status.Hash = ""
 	}
 
+	if authClient.Spec.Rotation == nil {
+		authClient.Status.LastRotation = nil
+		authClient.Status.PreviousSecretExpiresAt = nil
+	}
+
 	_, _, err := CreateOrUpdate[*corev1.Secret](ctx, types.NamespacedName{
-		Name:      fmt.Sprintf("auth-client-%s", authClient.Name),
+		Name:      GetSecretName(authClient),
 		Namespace: stack.Name,
 	},
 		func(t *corev1.Secret) error {
-			t.StringData = map[string]string{
-				"id":     authClient.Spec.ID,
-				"secret": authClient.Spec.Secret,
+			current, previous := authClient.Spec.Secret, ""
+			if authClient.Spec.Rotation != nil {
+				var err error
+				current, previous, err = rotateSecrets(authClient,
+					string(t.Data[SecretKey]), string(t.Data[PreviousSecretKey]), time.Now())
+				if err != nil {
+					return err
+				}
+				authClient.Status.Hash = hashSecrets(current, previous)
+			}
+
+			// Data is used instead of StringData so the secret is not updated when nothing changed
+			t.Data = map[string][]byte{
+				"id":      []byte(authClient.Spec.ID),
+				SecretKey: []byte(current),
+			}
+			if previous != "" {
+				t.Data[PreviousSecretKey] = []byte(previous)
 			}
 
 			return nil
@@ -73,6 +97,7 @@ func init() {
 		WithStackDependencyReconciler(Reconcile,
 			WithOwn[*v1beta1.AuthClient](&corev1.Secret{}),
 			WithOwn[*v1beta1.AuthClient](&v1beta1.ResourceReference{}),
+			WithRequeueAfter[*v1beta1.AuthClient](rotationRefreshInterval),
 		),
 	)
 }
@@ -0,0 +1,155 @@
+package authclients
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+)
+
+const (
+	// SecretKey is the key of the current secret of the client in the generated Secret
+	SecretKey = "secret"
+	// PreviousSecretKey is the key of the previous secret of the client in the generated Secret,
+	// it is only defined during the overlap period of a rotation
+	PreviousSecretKey = "previous-secret"
+)
+
+// rotationRefreshInterval is the resolution of the rotations
+const rotationRefreshInterval = time.Minute
+
+func GetSecretName(authClient *v1beta1.AuthClient) string {
+	return fmt.Sprintf("auth-client-%s", authClient.Name)
+}
+
+// rotateSecrets computes the secrets of a client having rotation enabled, from the secrets currently stored.
+// It generates a new secret when the interval is elapsed, and retires the previous one when the overlap is elapsed.
+func rotateSecrets(authClient *v1beta1.AuthClient, current, previous string, now time.Time) (string, string, error) {
+	rotation := authClient.Spec.Rotation
+	if rotation.Interval.Duration <= 0 {
+		return "", "", core.NewApplicationError().WithMessage("rotation interval must be positive")
+	}
+	if rotation.Overlap.Duration >= rotation.Interval.Duration {
+		return "", "", core.NewApplicationError().WithMessage("rotation overlap must be lower than the interval")
+	}
+
+	if current == "" {
+		current = authClient.Spec.Secret
+		if current == "" {
+			current = uuid.NewString()
+		}
+		authClient.Status.LastRotation = &metav1.Time{Time: now}
+	}
+	if authClient.Status.LastRotation == nil {
+		// The status has been lost, restart the interval from now
+		authClient.Status.LastRotation = &metav1.Time{Time: now}
+	}
+
+	if previous != "" {
+		expiresAt := authClient.Status.PreviousSecretExpiresAt
+		if expiresAt == nil || !now.Before(expiresAt.Time) {
+			previous = ""
+		}
+	}
+
+	if !now.Before(authClient.Status.LastRotation.Add(rotation.Interval.Duration)) {
+		previous = current
+		current = uuid.NewString()
+		authClient.Status.LastRotation = &metav1.Time{Time: now}
+		if rotation.Overlap.Duration == 0 {
+			previous = ""
+		} else {
+			authClient.Status.PreviousSecretExpiresAt = &metav1.Time{Time: now.Add(rotation.Overlap.Duration)}
+		}
+	}
+
+	if previous == "" {
+		authClient.Status.PreviousSecretExpiresAt = nil
+	}
+
+	return current, previous, nil
+}
+
+func hashSecrets(secrets ...string) string {
+	digest := sha256.New()
+	for _, secret := range secrets {
+		_, _ = fmt.Fprintln(digest, secret)
+	}
+	return base64.StdEncoding.EncodeToString(digest.Sum(nil))
+}
+
+// getServedHash returns the hash of the secrets of the client as served by the rolled out auth server
+func getServedHash(ctx core.Context, authClient *v1beta1.AuthClient) (string, error) {
+	auth := &v1beta1.Auth{}
+	ok, err := core.GetIfExists(ctx, authClient.Spec.Stack, auth)
+	if err != nil || !ok {
+		return "", err
+	}
+	return auth.Status.ClientSecretHashes[authClient.Name], nil
+}
+
+// GetSecret returns the secret to use for the client.
+// When the rotation is enabled, the secret is read from the Secret generated by the operator,
+// and the previous secret is returned until the auth server is rolled out with the new one.
+func GetSecret(ctx core.Context, stack *v1beta1.Stack, authClient *v1beta1.AuthClient) (string, error) {
+	if authClient.Spec.Rotation == nil {
+		return authClient.Spec.Secret, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Namespace: stack.Name,
+		Name:      GetSecretName(authClient),
+	}, secret); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return "", core.NewPendingError().WithMessage("waiting for secret of auth client %s", authClient.Name)
+		}
+		return "", err
+	}
+
+	servedHash, err := getServedHash(ctx, authClient)
+	if err != nil {
+		return "", err
+	}
+
+	return selectSecret(authClient, servedHash, string(secret.Data[SecretKey]), string(secret.Data[PreviousSecretKey])), nil
+}
+
+// selectSecret returns the previous secret while the auth server does not serve the current one, if any
+func selectSecret(authClient *v1beta1.AuthClient, servedHash, current, previous string) string {
+	if previous != "" && servedHash != authClient.Status.Hash {
+		return previous
+	}
+	return current
+}
+
+// GetAnnotations returns the annotations to add on the pods of the consumers of the client,
+// so they are restarted when the secret is rotated.
+// The hash served by the auth server is used, so the consumers are restarted only once the auth server
+// is rolled out with the new secret.
+func GetAnnotations(ctx core.Context, authClient *v1beta1.AuthClient) (map[string]string, error) {
+	if authClient == nil || authClient.Spec.Rotation == nil {
+		return map[string]string{}, nil
+	}
+
+	servedHash, err := getServedHash(ctx, authClient)
+	if err != nil {
+		return nil, err
+	}
+	if servedHash == "" {
+		return map[string]string{}, nil
+	}
+
+	return map[string]string{
+		"auth-client-secret-hash": servedHash,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package authclients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestRotateSecrets(t *testing.T) {
+	t.Parallel()
+
+	authClient := &v1beta1.AuthClient{
+		Spec: v1beta1.AuthClientSpec{
+			Secret: "initial",
+			Rotation: &v1beta1.AuthClientRotation{
+				Interval: metav1.Duration{Duration: 24 * time.Hour},
+				Overlap:  metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The initial secret is used on first reconciliation
+	current, previous, err := rotateSecrets(authClient, "", "", now)
+	require.NoError(t, err)
+	require.Equal(t, "initial", current)
+	require.Empty(t, previous)
+	require.Equal(t, now, authClient.Status.LastRotation.Time)
+	require.Nil(t, authClient.Status.PreviousSecretExpiresAt)
+
+	// Nothing change before the interval
+	current, previous, err = rotateSecrets(authClient, current, previous, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, "initial", current)
+	require.Empty(t, previous)
+
+	// The secret is rotated, the previous one is kept during the overlap
+	rotatedAt := now.Add(24 * time.Hour)
+	current, previous, err = rotateSecrets(authClient, current, previous, rotatedAt)
+	require.NoError(t, err)
+	require.NotEqual(t, "initial", current)
+	require.NotEmpty(t, current)
+	require.Equal(t, "initial", previous)
+	require.Equal(t, rotatedAt, authClient.Status.LastRotation.Time)
+	require.Equal(t, rotatedAt.Add(time.Hour), authClient.Status.PreviousSecretExpiresAt.Time)
+
+	newSecret := current
+	current, previous, err = rotateSecrets(authClient, current, previous, rotatedAt.Add(30*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, newSecret, current)
+	require.Equal(t, "initial", previous)
+
+	// The previous secret is retired after the overlap
+	current, previous, err = rotateSecrets(authClient, current, previous, rotatedAt.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, newSecret, current)
+	require.Empty(t, previous)
+	require.Nil(t, authClient.Status.PreviousSecretExpiresAt)
+
+	// Without overlap, the previous secret is retired immediately
+	authClient.Spec.Rotation.Overlap = metav1.Duration{}
+	current, previous, err = rotateSecrets(authClient, current, previous, rotatedAt.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.NotEqual(t, newSecret, current)
+	require.Empty(t, previous)
+	require.Nil(t, authClient.Status.PreviousSecretExpiresAt)
+
+	// The overlap must be lower than the interval
+	authClient.Spec.Rotation.Overlap = metav1.Duration{Duration: 48 * time.Hour}
+	_, _, err = rotateSecrets(authClient, current, previous, rotatedAt)
+	require.Error(t, err)
+}
+
+func TestSelectSecret(t *testing.T) {
+	t.Parallel()
+
+	authClient := &v1beta1.AuthClient{
+		Status: v1beta1.AuthClientStatus{
+			Hash: hashSecrets("current", "previous"),
+		},
+	}
+
+	// The previous secret is used until the auth server is rolled out with the current one
+	require.Equal(t, "previous", selectSecret(authClient, hashSecrets("previous"), "current", "previous"))
+	require.Equal(t, "previous", selectSecret(authClient, "", "current", "previous"))
+
+	// The current secret is used once served
+	require.Equal(t, "current", selectSecret(authClient, authClient.Status.Hash, "current", "previous"))
+
+	// The current secret is used when no previous secret is available
+	authClient.Status.Hash = hashSecrets("current")
+	require.Equal(t, "current", selectSecret(authClient, hashSecrets("current", "previous"), "current", ""))
+}
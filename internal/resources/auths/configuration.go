@@ -9,15 +9,74 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	. "github.com/formancehq/go-libs/v5/pkg/types/collections"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
 
 	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	. "github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/authclients"
 )
 
+func authClientEnvVarSuffix(authClient *v1beta1.AuthClient) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(authClient.Name, "-", "_"), ".", "_"))
+}
+
 func AuthClientSecretToEnvVars(authClient *v1beta1.AuthClient) corev1.EnvVar {
-	name := authClient.Name
-	name = strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(name, "-", "_"), ".", "_"))
-	return EnvFromSecret("AUTH_CLIENT_SECRET_"+name, authClient.Spec.SecretFromSecret.Name, authClient.Spec.SecretFromSecret.Key)
+	if authClient.Spec.Rotation != nil {
+		return EnvFromSecret("AUTH_CLIENT_SECRET_"+authClientEnvVarSuffix(authClient),
+			authclients.GetSecretName(authClient), authclients.SecretKey)
+	}
+	return EnvFromSecret("AUTH_CLIENT_SECRET_"+authClientEnvVarSuffix(authClient),
+		authClient.Spec.SecretFromSecret.Name, authClient.Spec.SecretFromSecret.Key)
+}
+
+// AuthClientPreviousSecretToEnvVars returns the env var containing the previous secret of a client
+// during the overlap period of a rotation.
+// The reference is optional as the key is removed from the Secret at the end of the overlap,
+// before the auth server is rolled out without it.
+func AuthClientPreviousSecretToEnvVars(authClient *v1beta1.AuthClient) corev1.EnvVar {
+	ret := EnvFromSecret("AUTH_CLIENT_PREVIOUS_SECRET_"+authClientEnvVarSuffix(authClient),
+		authclients.GetSecretName(authClient), authclients.PreviousSecretKey)
+	ret.ValueFrom.SecretKeyRef.Optional = pointer.For(true)
+	return ret
+}
+
+// hasPreviousSecret returns whether the previous secret of a client must be accepted by the auth server
+func hasPreviousSecret(authClient *v1beta1.AuthClient, version string) bool {
+	return authClient.Spec.Rotation != nil && authClient.Status.PreviousSecretExpiresAt != nil &&
+		isSupported(version, clientSecretsVersion)
+}
+
+// authClientConfiguration is the configuration of a client on the auth server.
+// A client can have many secrets, as the previous secret is still accepted during the overlap period of a rotation.
+// The list is only configured on the versions of the auth server reading it.
+type authClientConfiguration struct {
+	Spec    v1beta1.AuthClientSpec `yaml:",inline"`
+	Secrets []string               `yaml:"secrets,omitempty"`
+}
+
+func authClientToConfiguration(from *v1beta1.AuthClient, version string) authClientConfiguration {
+	spec := from.Spec
+	if from.Spec.SecretFromSecret != nil || from.Spec.Rotation != nil {
+		spec.Secret = "$" + AuthClientSecretToEnvVars(from).Name
+	}
+	if !isSupported(version, clientSecretsVersion) {
+		return authClientConfiguration{
+			Spec: spec,
+		}
+	}
+
+	secrets := []string{}
+	if spec.Secret != "" {
+		secrets = append(secrets, spec.Secret)
+	}
+	if hasPreviousSecret(from, version) {
+		secrets = append(secrets, "$"+AuthClientPreviousSecretToEnvVars(from).Name)
+	}
+
+	return authClientConfiguration{
+		Spec:    spec,
+		Secrets: secrets,
+	}
 }
 
 func createConfiguration(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth, items []*v1beta1.AuthClient, version string) (*corev1.ConfigMap, error) {
@@ -26,14 +85,10 @@ func createConfiguration(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth,
 	})
 
 	yamlData, err := yaml.Marshal(struct {
-		Clients []v1beta1.AuthClientSpec `yaml:"clients"`
+		Clients []authClientConfiguration `yaml:"clients"`
 	}{
-		Clients: Map(items, func(from *v1beta1.AuthClient) v1beta1.AuthClientSpec {
-			if from.Spec.SecretFromSecret != nil {
-				envVar := AuthClientSecretToEnvVars(from)
-				from.Spec.Secret = "$" + envVar.Name
-			}
-			return from.Spec
+		Clients: Map(items, func(from *v1beta1.AuthClient) authClientConfiguration {
+			return authClientToConfiguration(from, version)
 		}),
 	})
 	if err != nil {
@@ -0,0 +1,118 @@
+package auths
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestAuthClientToConfiguration(t *testing.T) {
+	t.Parallel()
+
+	rotated := &v1beta1.AuthClient{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "client0",
+		},
+		Spec: v1beta1.AuthClientSpec{
+			ID: "client0",
+			Rotation: &v1beta1.AuthClientRotation{
+				Interval: metav1.Duration{Duration: 24 * time.Hour},
+				Overlap:  metav1.Duration{Duration: time.Hour},
+			},
+		},
+		Status: v1beta1.AuthClientStatus{
+			PreviousSecretExpiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)},
+		},
+	}
+
+	type testCase struct {
+		name            string
+		version         string
+		expectedSecrets []string
+	}
+	for _, tc := range []testCase{
+		{
+			name:            "recent version",
+			version:         "v3.1.0",
+			expectedSecrets: []string{"$AUTH_CLIENT_SECRET_CLIENT0", "$AUTH_CLIENT_PREVIOUS_SECRET_CLIENT0"},
+		},
+		{
+			name:            "branch",
+			version:         "main",
+			expectedSecrets: []string{"$AUTH_CLIENT_SECRET_CLIENT0", "$AUTH_CLIENT_PREVIOUS_SECRET_CLIENT0"},
+		},
+		{
+			name:    "old version",
+			version: "v2.0.0",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			configuration := authClientToConfiguration(rotated, tc.version)
+			require.Equal(t, "$AUTH_CLIENT_SECRET_CLIENT0", configuration.Spec.Secret)
+			require.Equal(t, tc.expectedSecrets, configuration.Secrets)
+			require.Equal(t, tc.expectedSecrets != nil, hasPreviousSecret(rotated, tc.version))
+		})
+	}
+}
+
+func TestAuthClientPreviousSecretToEnvVars(t *testing.T) {
+	t.Parallel()
+
+	env := AuthClientPreviousSecretToEnvVars(&v1beta1.AuthClient{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "client0",
+		},
+	})
+	require.Equal(t, "AUTH_CLIENT_PREVIOUS_SECRET_CLIENT0", env.Name)
+	// The key is removed from the secret at the end of the overlap
+	require.NotNil(t, env.ValueFrom.SecretKeyRef.Optional)
+	require.True(t, *env.ValueFrom.SecretKeyRef.Optional)
+}
+
+func TestUpdateClientSecretHashes(t *testing.T) {
+	t.Parallel()
+
+	authClients := []*v1beta1.AuthClient{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rotated"},
+			Spec: v1beta1.AuthClientSpec{
+				Rotation: &v1beta1.AuthClientRotation{},
+			},
+			Status: v1beta1.AuthClientStatus{Hash: "hash"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "static"},
+			Status:     v1beta1.AuthClientStatus{Hash: "other"},
+		},
+	}
+	auth := &v1beta1.Auth{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+	}
+
+	// Nothing is recorded while the auth server is rolling out
+	auth.GetConditions().AppendOrReplace(*v1beta1.NewCondition("DeploymentReady", 1).
+		SetReason("Auth").
+		SetStatus(metav1.ConditionFalse), v1beta1.ConditionTypeMatch("DeploymentReady"))
+	updateClientSecretHashes(auth, authClients)
+	require.Nil(t, auth.Status.ClientSecretHashes)
+
+	// The hashes of the rotated clients are recorded once rolled out
+	auth.GetConditions().AppendOrReplace(*v1beta1.NewCondition("DeploymentReady", 1).
+		SetReason("Auth"), v1beta1.ConditionTypeMatch("DeploymentReady"))
+	updateClientSecretHashes(auth, authClients)
+	require.Equal(t, map[string]string{"rotated": "hash"}, auth.Status.ClientSecretHashes)
+
+	// The recorded hashes are kept while the next rollout is in progress
+	authClients[0].Status.Hash = "new-hash"
+	auth.GetConditions().AppendOrReplace(*v1beta1.NewCondition("DeploymentReady", 1).
+		SetReason("Auth").
+		SetStatus(metav1.ConditionFalse), v1beta1.ConditionTypeMatch("DeploymentReady"))
+	updateClientSecretHashes(auth, authClients)
+	require.Equal(t, map[string]string{"rotated": "hash"}, auth.Status.ClientSecretHashes)
+}
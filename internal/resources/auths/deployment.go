@@ -88,14 +88,33 @@ func createDeployment(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth, dat
 			},
 		})
 	}
+	signingKey, err := reconcileSigningKey(ctx, stack, auth, version)
+	if err != nil {
+		return err
+	}
+	env = append(env, signingKey.env...)
+	if signingKey.hash != "" {
+		annotations["signing-key-hash"] = signingKey.hash
+	}
 	if auth.Spec.DelegatedOIDCServer != nil {
 		if auth.Spec.DelegatedOIDCServer.ClientSecret != "" && auth.Spec.DelegatedOIDCServer.ClientSecretFromSecret != nil {
 			return fmt.Errorf("cannot specify signing key using both .spec.DelegatedOIDCServer.ClientSecret and .spec.DelegatedOIDCServer.ClientSecretFromSecret fields")
 		}
+		delegatedOIDCServer, err := resolveDelegatedOIDCServer(auth.Spec.DelegatedOIDCServer)
+		if err != nil {
+			return err
+		}
+		setOIDCReachableCondition(ctx, defaultDiscoveryChecker, auth, delegatedOIDCServer)
+
 		env = append(env,
 			Env("DELEGATED_CLIENT_ID", auth.Spec.DelegatedOIDCServer.ClientID),
-			Env("DELEGATED_ISSUER", auth.Spec.DelegatedOIDCServer.Issuer),
+			Env("DELEGATED_ISSUER", delegatedOIDCServer.issuer),
 		)
+		delegatedOIDCEnv, err := delegatedOIDCOptionsEnv(auth.Spec.DelegatedOIDCServer, delegatedOIDCServer, version)
+		if err != nil {
+			return err
+		}
+		env = append(env, delegatedOIDCEnv...)
 
 		if auth.Spec.DelegatedOIDCServer.ClientSecret != "" {
 			env = append(env,
@@ -127,16 +146,23 @@ func createDeployment(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth, dat
 
 	hashList := make([]string, 0)
 	hashList = collectionutils.Reduce(clients, func(acc []string, from *v1beta1.AuthClient) []string {
-		if from.Spec.SecretFromSecret != nil {
+		if from.Spec.SecretFromSecret != nil || from.Spec.Rotation != nil {
 			acc = append(acc, from.Status.Hash)
 		}
 		return acc
 	}, hashList)
 	annotations["auth-clients-secrets"] = HashFromHash(hashList...)
 	for _, client := range clients {
-		if client.Spec.SecretFromSecret != nil {
+		if client.Spec.SecretFromSecret != nil || client.Spec.Rotation != nil {
 			env = append(env, AuthClientSecretToEnvVars(client))
 		}
+		if hasPreviousSecret(client, version) {
+			env = append(env, AuthClientPreviousSecretToEnvVars(client))
+		}
+	}
+
+	if auth.Spec.DelegatedOIDCServer == nil {
+		setOIDCReachableCondition(ctx, defaultDiscoveryChecker, auth, nil)
 	}
 
 	if stack.Spec.Dev || auth.Spec.Dev {
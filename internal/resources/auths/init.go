@@ -58,6 +58,29 @@ func checkAuthClientsReconciliation(ctx Context, auth *v1beta1.Auth) ([]*v1beta1
 	return authClients, nil
 }
 
+// updateClientSecretHashes records the hashes of the secrets of the rotated clients once the auth server is rolled out,
+// so the consumers of the clients are restarted with a new secret only when the auth server accepts it.
+func updateClientSecretHashes(auth *v1beta1.Auth, authClients []*v1beta1.AuthClient) {
+	if !auth.GetConditions().Check(v1beta1.AndConditions(
+		v1beta1.ConditionTypeMatch("DeploymentReady"),
+		v1beta1.ConditionReasonMatch("Auth"),
+		v1beta1.ConditionGenerationMatch(auth.Generation),
+	)) {
+		return
+	}
+
+	hashes := map[string]string{}
+	for _, authClient := range authClients {
+		if authClient.Spec.Rotation != nil && authClient.Status.Hash != "" {
+			hashes[authClient.Name] = authClient.Status.Hash
+		}
+	}
+	if len(hashes) == 0 {
+		hashes = nil
+	}
+	auth.Status.ClientSecretHashes = hashes
+}
+
 func Reconcile(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth, version string) error {
 
 	authClients, err := checkAuthClientsReconciliation(ctx, auth)
@@ -76,7 +99,7 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth, version st
 	}
 
 	if !database.Status.Ready {
-		return NewPendingError().WithMessage("database is not ready")
+		return NewPendingError().WithMessage("database is not ready").OnlyOnWatchEvent()
 	}
 
 	imageConfiguration, err := registries.GetFormanceImage(ctx, stack, "auth", version)
@@ -103,6 +126,7 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth, version st
 	}
 
 	auth.Status.Clients = Map(authClients, (*v1beta1.AuthClient).GetName)
+	updateClientSecretHashes(auth, authClients)
 
 	return nil
 }
@@ -119,6 +143,7 @@ func init() {
 			WithWatchSettings[*v1beta1.Auth](),
 			WithWatchDependency[*v1beta1.Auth](&v1beta1.AuthClient{}),
 			databases.Watch[*v1beta1.Auth](),
+			WithRequeueAfter[*v1beta1.Auth](signingKeyRefreshInterval),
 		),
 	)
 }
@@ -0,0 +1,227 @@
+package auths
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+)
+
+const (
+	discoveryTimeout = 5 * time.Second
+	// discoveryInterval is the minimal delay between two checks of the discovery document of an issuer
+	discoveryInterval = 5 * time.Minute
+	// discoveryRetryInterval is the minimal delay between two checks of an issuer which failed
+	discoveryRetryInterval = 30 * time.Second
+)
+
+var defaultOIDCScopes = []string{"openid", "profile", "email"}
+
+// delegatedOIDCServer is the configuration of the delegated oidc server, once the defaults of the preset applied
+type delegatedOIDCServer struct {
+	issuer     string
+	scopes     []string
+	emailClaim string
+}
+
+func resolveDelegatedOIDCServer(configuration *v1beta1.DelegatedOIDCServerConfiguration) (*delegatedOIDCServer, error) {
+	ret := &delegatedOIDCServer{
+		issuer:     configuration.Issuer,
+		scopes:     configuration.Scopes,
+		emailClaim: configuration.EmailClaim,
+	}
+
+	if preset := configuration.Preset; preset != nil {
+		var (
+			issuer     string
+			emailClaim = "email"
+		)
+		switch preset.Type {
+		case v1beta1.OIDCProviderKeycloak:
+			if preset.URL == "" || preset.Realm == "" {
+				return nil, NewApplicationError().WithMessage("url and realm are required for keycloak preset")
+			}
+			issuer = fmt.Sprintf("%s/realms/%s", strings.TrimSuffix(preset.URL, "/"), preset.Realm)
+		case v1beta1.OIDCProviderDex:
+			if preset.URL == "" {
+				return nil, NewApplicationError().WithMessage("url is required for dex preset")
+			}
+			issuer = strings.TrimSuffix(preset.URL, "/")
+		case v1beta1.OIDCProviderAzureAD:
+			if preset.TenantID == "" {
+				return nil, NewApplicationError().WithMessage("tenantID is required for azure-ad preset")
+			}
+			issuer = fmt.Sprintf("https://login.microsoftonline.com/%s/v2.0", preset.TenantID)
+			// The email claim is optional on azure AD, the login of the user is always available
+			emailClaim = "preferred_username"
+		case v1beta1.OIDCProviderGoogle:
+			issuer = "https://accounts.google.com"
+		default:
+			return nil, NewApplicationError().WithMessage("unknown oidc provider preset '%s'", preset.Type)
+		}
+
+		if ret.issuer == "" {
+			ret.issuer = issuer
+		}
+		if ret.emailClaim == "" {
+			ret.emailClaim = emailClaim
+		}
+		if len(ret.scopes) == 0 {
+			ret.scopes = defaultOIDCScopes
+		}
+	}
+
+	if ret.issuer == "" {
+		return nil, NewApplicationError().WithMessage("issuer of the delegated oidc server is required")
+	}
+
+	return ret, nil
+}
+
+// delegatedOIDCOptionsEnv returns the environment variables configuring the scopes and the email claim.
+// They are only read by recent versions of the auth server: the defaults of the presets are skipped on older
+// versions, while explicit values are rejected.
+func delegatedOIDCOptionsEnv(configuration *v1beta1.DelegatedOIDCServerConfiguration, server *delegatedOIDCServer, version string) ([]corev1.EnvVar, error) {
+	if !isSupported(version, delegatedOIDCOptionsVersion) {
+		if len(configuration.Scopes) > 0 || configuration.EmailClaim != "" {
+			return nil, NewApplicationError().WithMessage("scopes and emailClaim of the delegated oidc server require auth >= %s, actual: %s",
+				delegatedOIDCOptionsVersion, version)
+		}
+		return nil, nil
+	}
+
+	env := make([]corev1.EnvVar, 0)
+	if len(server.scopes) > 0 {
+		env = append(env, Env("DELEGATED_SCOPES", strings.Join(server.scopes, " ")))
+	}
+	if server.emailClaim != "" {
+		env = append(env, Env("DELEGATED_EMAIL_CLAIM", server.emailClaim))
+	}
+
+	return env, nil
+}
+
+type openIDConfiguration struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// discoveryChecker checks the discovery documents of the issuers, and caches the results,
+// so the issuers are not requested on each reconciliation
+type discoveryChecker struct {
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu      sync.Mutex
+	results map[string]discoveryResult
+}
+
+type discoveryResult struct {
+	checkedAt time.Time
+	err       error
+}
+
+func newDiscoveryChecker(httpClient *http.Client) *discoveryChecker {
+	return &discoveryChecker{
+		httpClient: httpClient,
+		now:        time.Now,
+		results:    map[string]discoveryResult{},
+	}
+}
+
+var defaultDiscoveryChecker = newDiscoveryChecker(&http.Client{
+	Timeout: discoveryTimeout,
+})
+
+func (c *discoveryChecker) check(ctx context.Context, issuer string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if result, ok := c.results[issuer]; ok {
+		interval := discoveryInterval
+		if result.err != nil {
+			interval = discoveryRetryInterval
+		}
+		if now.Sub(result.checkedAt) < interval {
+			return result.err
+		}
+	}
+
+	err := checkOIDCDiscovery(ctx, c.httpClient, issuer)
+	c.results[issuer] = discoveryResult{
+		checkedAt: now,
+		err:       err,
+	}
+
+	return err
+}
+
+// checkOIDCDiscovery validates the discovery document of the issuer
+func checkOIDCDiscovery(ctx context.Context, httpClient *http.Client, issuer string) error {
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rsp.Body.Close()
+	}()
+
+	if rsp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d fetching %s: %s", rsp.StatusCode, discoveryURL, string(data))
+	}
+
+	configuration := openIDConfiguration{}
+	if err := json.NewDecoder(rsp.Body).Decode(&configuration); err != nil {
+		return fmt.Errorf("decoding discovery document: %w", err)
+	}
+
+	if strings.TrimSuffix(configuration.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return fmt.Errorf("issuer mismatch, discovery document advertise '%s'", configuration.Issuer)
+	}
+	if configuration.AuthorizationEndpoint == "" || configuration.TokenEndpoint == "" || configuration.JWKSURI == "" {
+		return fmt.Errorf("discovery document is missing authorization_endpoint, token_endpoint or jwks_uri")
+	}
+
+	return nil
+}
+
+// setOIDCReachableCondition checks the delegated oidc server is reachable and reports the result as a condition.
+// The condition is informational: an unreachable server does not block the reconciliation, as it could be
+// temporarily unavailable.
+func setOIDCReachableCondition(ctx context.Context, checker *discoveryChecker, auth *v1beta1.Auth, server *delegatedOIDCServer) {
+	if server == nil {
+		auth.GetConditions().Delete(v1beta1.ConditionTypeMatch(v1beta1.ConditionTypeOIDCReachable))
+		return
+	}
+
+	condition := v1beta1.NewCondition(v1beta1.ConditionTypeOIDCReachable, auth.Generation).
+		SetMessage("Discovery document of the issuer is valid").
+		SetReason("DiscoveryValid")
+	if err := checker.check(ctx, server.issuer); err != nil {
+		condition.Fail(err.Error()).SetReason("DiscoveryFailed")
+	}
+	auth.GetConditions().AppendOrReplace(*condition, v1beta1.ConditionTypeMatch(v1beta1.ConditionTypeOIDCReachable))
+}
@@ -0,0 +1,271 @@
+package auths
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+)
+
+func TestResolveDelegatedOIDCServer(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		configuration v1beta1.DelegatedOIDCServerConfiguration
+		expected      *delegatedOIDCServer
+		expectError   bool
+	}
+	for _, tc := range []testCase{
+		{
+			name: "raw issuer",
+			configuration: v1beta1.DelegatedOIDCServerConfiguration{
+				Issuer: "https://issuer.example.com",
+			},
+			expected: &delegatedOIDCServer{
+				issuer: "https://issuer.example.com",
+			},
+		},
+		{
+			name: "keycloak",
+			configuration: v1beta1.DelegatedOIDCServerConfiguration{
+				Preset: &v1beta1.OIDCProviderPreset{
+					Type:  v1beta1.OIDCProviderKeycloak,
+					URL:   "https://keycloak.example.com/",
+					Realm: "formance",
+				},
+			},
+			expected: &delegatedOIDCServer{
+				issuer:     "https://keycloak.example.com/realms/formance",
+				scopes:     []string{"openid", "profile", "email"},
+				emailClaim: "email",
+			},
+		},
+		{
+			name: "keycloak without realm",
+			configuration: v1beta1.DelegatedOIDCServerConfiguration{
+				Preset: &v1beta1.OIDCProviderPreset{
+					Type: v1beta1.OIDCProviderKeycloak,
+					URL:  "https://keycloak.example.com",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "azure AD with custom scopes",
+			configuration: v1beta1.DelegatedOIDCServerConfiguration{
+				Preset: &v1beta1.OIDCProviderPreset{
+					Type:     v1beta1.OIDCProviderAzureAD,
+					TenantID: "tenant",
+				},
+				Scopes: []string{"openid"},
+			},
+			expected: &delegatedOIDCServer{
+				issuer:     "https://login.microsoftonline.com/tenant/v2.0",
+				scopes:     []string{"openid"},
+				emailClaim: "preferred_username",
+			},
+		},
+		{
+			name: "google with issuer override",
+			configuration: v1beta1.DelegatedOIDCServerConfiguration{
+				Preset: &v1beta1.OIDCProviderPreset{
+					Type: v1beta1.OIDCProviderGoogle,
+				},
+				Issuer: "https://accounts.example.com",
+			},
+			expected: &delegatedOIDCServer{
+				issuer:     "https://accounts.example.com",
+				scopes:     []string{"openid", "profile", "email"},
+				emailClaim: "email",
+			},
+		},
+		{
+			name:          "missing issuer",
+			configuration: v1beta1.DelegatedOIDCServerConfiguration{},
+			expectError:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ret, err := resolveDelegatedOIDCServer(&tc.configuration)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ret)
+		})
+	}
+}
+
+func TestOIDCReachableCondition(t *testing.T) {
+	t.Parallel()
+
+	var (
+		srv              *httptest.Server
+		advertisedIssuer = ""
+		requests         atomic.Int32
+	)
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/dex/.well-known/openid-configuration" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		issuer := advertisedIssuer
+		if issuer == "" {
+			issuer = srv.URL + "/dex"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 issuer,
+			"authorization_endpoint": srv.URL + "/dex/auth",
+			"token_endpoint":         srv.URL + "/dex/token",
+			"jwks_uri":               srv.URL + "/dex/keys",
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	auth := &v1beta1.Auth{}
+	server, err := resolveDelegatedOIDCServer(&v1beta1.DelegatedOIDCServerConfiguration{
+		Preset: &v1beta1.OIDCProviderPreset{
+			Type: v1beta1.OIDCProviderDex,
+			URL:  srv.URL + "/dex/",
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	checker := newDiscoveryChecker(srv.Client())
+	checker.now = func() time.Time { return now }
+
+	setOIDCReachableCondition(context.Background(), checker, auth, server)
+	condition := auth.GetConditions().Get(v1beta1.ConditionTypeOIDCReachable)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, int32(1), requests.Load())
+
+	// The result is cached
+	advertisedIssuer = "https://other.example.com"
+	setOIDCReachableCondition(context.Background(), checker, auth, server)
+	require.Equal(t, metav1.ConditionTrue, auth.GetConditions().Get(v1beta1.ConditionTypeOIDCReachable).Status)
+	require.Equal(t, int32(1), requests.Load())
+
+	// The issuer advertised by the discovery document must match
+	now = now.Add(discoveryInterval)
+	setOIDCReachableCondition(context.Background(), checker, auth, server)
+	condition = auth.GetConditions().Get(v1beta1.ConditionTypeOIDCReachable)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Contains(t, condition.Message, "issuer mismatch")
+	require.Len(t, *auth.GetConditions(), 1)
+	require.Equal(t, int32(2), requests.Load())
+
+	// Failures are checked again sooner
+	advertisedIssuer = ""
+	now = now.Add(discoveryRetryInterval)
+	setOIDCReachableCondition(context.Background(), checker, auth, server)
+	require.Equal(t, metav1.ConditionTrue, auth.GetConditions().Get(v1beta1.ConditionTypeOIDCReachable).Status)
+	require.Equal(t, int32(3), requests.Load())
+
+	// Unreachable issuer
+	server.issuer = srv.URL + "/unknown"
+	setOIDCReachableCondition(context.Background(), checker, auth, server)
+	condition = auth.GetConditions().Get(v1beta1.ConditionTypeOIDCReachable)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, "DiscoveryFailed", condition.Reason)
+
+	// The condition is removed when the delegated server is removed
+	setOIDCReachableCondition(context.Background(), checker, auth, nil)
+	require.Nil(t, auth.GetConditions().Get(v1beta1.ConditionTypeOIDCReachable))
+}
+
+func TestDelegatedOIDCOptionsEnv(t *testing.T) {
+	t.Parallel()
+
+	preset := &v1beta1.DelegatedOIDCServerConfiguration{
+		Preset: &v1beta1.OIDCProviderPreset{
+			Type: v1beta1.OIDCProviderGoogle,
+		},
+	}
+	explicit := &v1beta1.DelegatedOIDCServerConfiguration{
+		Issuer:     "https://issuer.example.com",
+		EmailClaim: "upn",
+	}
+
+	type testCase struct {
+		name          string
+		configuration *v1beta1.DelegatedOIDCServerConfiguration
+		version       string
+		expected      []corev1.EnvVar
+		expectError   bool
+	}
+	for _, tc := range []testCase{
+		{
+			name:          "preset on recent version",
+			configuration: preset,
+			version:       "v3.1.0",
+			expected: []corev1.EnvVar{
+				core.Env("DELEGATED_SCOPES", "openid profile email"),
+				core.Env("DELEGATED_EMAIL_CLAIM", "email"),
+			},
+		},
+		{
+			name:          "preset on branch",
+			configuration: preset,
+			version:       "main",
+			expected: []corev1.EnvVar{
+				core.Env("DELEGATED_SCOPES", "openid profile email"),
+				core.Env("DELEGATED_EMAIL_CLAIM", "email"),
+			},
+		},
+		{
+			name:          "preset on old version",
+			configuration: preset,
+			version:       "v2.0.0",
+		},
+		{
+			name:          "explicit values on recent version",
+			configuration: explicit,
+			version:       "v3.1.0",
+			expected: []corev1.EnvVar{
+				core.Env("DELEGATED_EMAIL_CLAIM", "upn"),
+			},
+		},
+		{
+			name:          "explicit values on old version",
+			configuration: explicit,
+			version:       "v2.0.0",
+			expectError:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := resolveDelegatedOIDCServer(tc.configuration)
+			require.NoError(t, err)
+
+			env, err := delegatedOIDCOptionsEnv(tc.configuration, server, tc.version)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if len(tc.expected) == 0 {
+				require.Empty(t, env)
+			} else {
+				require.Equal(t, tc.expected, env)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package auths
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+)
+
+const (
+	signingKeySecretName  = "auth-signing-key"
+	signingKeyKey         = "signing-key"
+	previousSigningKeyKey = "previous-signing-key"
+
+	defaultSigningKeyAlgorithm = "RS256"
+)
+
+// signingKeyRefreshInterval is the resolution of the rotations of the signing key
+const signingKeyRefreshInterval = 5 * time.Minute
+
+type signingKeyConfiguration struct {
+	env  []corev1.EnvVar
+	hash string
+}
+
+// reconcileSigningKey generates the signing key of the auth server in a secret of the stack,
+// when no key is provided and an algorithm or a rotation is configured.
+// Otherwise, the auth server keeps using the provided key, or its own one.
+// When rotation is enabled, a new key is generated on each interval and the previous one is still published
+// during the grace period, so the tokens signed with it can still be validated.
+// The secret is not watched by the auth reconciler, so it is kept when the stack is disabled.
+func reconcileSigningKey(ctx Context, stack *v1beta1.Stack, auth *v1beta1.Auth, version string) (*signingKeyConfiguration, error) {
+	if !isSigningKeyGenerated(auth) {
+		auth.Status.SigningKey = nil
+
+		// Only remove the secret if it has been generated by the operator,
+		// as the provided signing key could use the same name
+		secret := &corev1.Secret{}
+		if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+			Namespace: stack.Name,
+			Name:      signingKeySecretName,
+		}, secret); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		} else if err == nil && metav1.IsControlledBy(secret, auth) {
+			LogDeletion(ctx, secret, "auths.reconcileSigningKey")
+			if err := ctx.GetClient().Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+		}
+
+		return &signingKeyConfiguration{}, nil
+	}
+
+	if err := checkSigningKeyVersion(auth, version); err != nil {
+		return nil, err
+	}
+
+	var current, previous string
+	_, _, err := CreateOrUpdate[*corev1.Secret](ctx, types.NamespacedName{
+		Namespace: stack.Name,
+		Name:      signingKeySecretName,
+	}, func(t *corev1.Secret) error {
+		var err error
+		current, previous, err = rotateSigningKeys(auth,
+			string(t.Data[signingKeyKey]), string(t.Data[previousSigningKeyKey]), time.Now())
+		if err != nil {
+			return err
+		}
+
+		t.Data = map[string][]byte{
+			signingKeyKey: []byte(current),
+		}
+		if previous != "" {
+			t.Data[previousSigningKeyKey] = []byte(previous)
+		}
+
+		return nil
+	}, WithController[*corev1.Secret](ctx.GetScheme(), auth))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &signingKeyConfiguration{
+		env: []corev1.EnvVar{
+			EnvFromSecret("SIGNING_KEY", signingKeySecretName, signingKeyKey),
+		},
+	}
+	if previous != "" {
+		// The reference is optional as the key is removed from the secret at the end of the grace period,
+		// before the auth server is rolled out without it
+		previousEnv := EnvFromSecret("PREVIOUS_SIGNING_KEY", signingKeySecretName, previousSigningKeyKey)
+		previousEnv.ValueFrom.SecretKeyRef.Optional = pointer.For(true)
+		ret.env = append(ret.env, previousEnv)
+	}
+
+	digest := sha256.New()
+	_, _ = fmt.Fprintln(digest, current)
+	_, _ = fmt.Fprintln(digest, previous)
+	ret.hash = base64.StdEncoding.EncodeToString(digest.Sum(nil))
+
+	return ret, nil
+}
+
+// isSigningKeyGenerated returns whether the operator generates the signing key.
+// The key is only generated when explicitly configured, as replacing the key of the auth server
+// invalidates the tokens it has signed.
+func isSigningKeyGenerated(auth *v1beta1.Auth) bool {
+	if auth.Spec.SigningKey != "" || auth.Spec.SigningKeyFromSecret != nil {
+		return false
+	}
+	return auth.Spec.SigningKeyAlgorithm != "" || auth.Spec.SigningKeyRotation != nil
+}
+
+// checkSigningKeyVersion rejects the options of the signing key not supported by the version of the auth server.
+// A grace period is rejected on the versions not reading PREVIOUS_SIGNING_KEY, as the tokens signed with the previous
+// key would be silently rejected after a rotation.
+func checkSigningKeyVersion(auth *v1beta1.Auth, version string) error {
+	if auth.Spec.SigningKeyAlgorithm == "ES256" && !isSupported(version, es256SigningKeyVersion) {
+		return NewApplicationError().WithMessage("ES256 signing key requires auth >= %s, actual: %s",
+			es256SigningKeyVersion, version)
+	}
+
+	rotation := auth.Spec.SigningKeyRotation
+	if rotation == nil || rotation.GracePeriod.Duration == 0 || isSupported(version, previousSigningKeyVersion) {
+		return nil
+	}
+	return NewApplicationError().WithMessage("gracePeriod of the signing key rotation requires auth >= %s, actual: %s",
+		previousSigningKeyVersion, version)
+}
+
+// rotateSigningKeys computes the signing keys from the keys currently stored, and updates the status of the auth.
+func rotateSigningKeys(auth *v1beta1.Auth, current, previous string, now time.Time) (string, string, error) {
+	algorithm := auth.Spec.SigningKeyAlgorithm
+	if algorithm == "" {
+		algorithm = defaultSigningKeyAlgorithm
+	}
+
+	rotation := auth.Spec.SigningKeyRotation
+	if rotation != nil {
+		if rotation.Interval.Duration <= 0 {
+			return "", "", NewApplicationError().WithMessage("signing key rotation interval must be positive")
+		}
+		if rotation.GracePeriod.Duration >= rotation.Interval.Duration {
+			return "", "", NewApplicationError().WithMessage("signing key grace period must be lower than the interval")
+		}
+	}
+
+	status := auth.Status.SigningKey
+	if status == nil {
+		status = &v1beta1.SigningKeyStatus{}
+		auth.Status.SigningKey = status
+	}
+
+	if current == "" {
+		key, err := generateSigningKey(algorithm)
+		if err != nil {
+			return "", "", err
+		}
+		status.Algorithm = algorithm
+		status.LastRotation = &metav1.Time{Time: now}
+		status.PreviousKeyExpiresAt = nil
+		return key, "", nil
+	}
+	if status.LastRotation == nil {
+		// The status has been lost, restart the interval from now
+		status.LastRotation = &metav1.Time{Time: now}
+	}
+	if status.Algorithm == "" {
+		status.Algorithm = signingKeyAlgorithm(current)
+	}
+
+	if previous != "" && (rotation == nil || status.PreviousKeyExpiresAt == nil ||
+		!now.Before(status.PreviousKeyExpiresAt.Time)) {
+		previous = ""
+	}
+
+	// The key is also rotated when the algorithm is changed
+	if status.Algorithm != algorithm ||
+		(rotation != nil && !now.Before(status.LastRotation.Add(rotation.Interval.Duration))) {
+		key, err := generateSigningKey(algorithm)
+		if err != nil {
+			return "", "", err
+		}
+		previous = current
+		current = key
+		status.Algorithm = algorithm
+		status.LastRotation = &metav1.Time{Time: now}
+		if rotation == nil || rotation.GracePeriod.Duration == 0 {
+			previous = ""
+		} else {
+			status.PreviousKeyExpiresAt = &metav1.Time{Time: now.Add(rotation.GracePeriod.Duration)}
+		}
+	}
+
+	if previous == "" {
+		status.PreviousKeyExpiresAt = nil
+	}
+
+	return current, previous, nil
+}
+
+func generateSigningKey(algorithm string) (string, error) {
+	switch algorithm {
+	case "RS256":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return "", err
+		}
+		return string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})), nil
+	case "ES256":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return "", err
+		}
+		data, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return "", err
+		}
+		return string(pem.EncodeToMemory(&pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: data,
+		})), nil
+	default:
+		return "", NewApplicationError().WithMessage("unsupported signing key algorithm '%s'", algorithm)
+	}
+}
+
+// signingKeyAlgorithm returns the algorithm of a key previously generated
+func signingKeyAlgorithm(key string) string {
+	block, _ := pem.Decode([]byte(key))
+	if block != nil && block.Type == "EC PRIVATE KEY" {
+		return "ES256"
+	}
+	return defaultSigningKeyAlgorithm
+}
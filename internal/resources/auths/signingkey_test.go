@@ -0,0 +1,165 @@
+package auths
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestRotateSigningKeys(t *testing.T) {
+	t.Parallel()
+
+	auth := &v1beta1.Auth{
+		Spec: v1beta1.AuthSpec{
+			SigningKeyRotation: &v1beta1.SigningKeyRotation{
+				Interval:    metav1.Duration{Duration: 24 * time.Hour},
+				GracePeriod: metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A RSA key is generated on first reconciliation
+	current, previous, err := rotateSigningKeys(auth, "", "", now)
+	require.NoError(t, err)
+	require.Empty(t, previous)
+	block, _ := pem.Decode([]byte(current))
+	require.NotNil(t, block)
+	_, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	require.Equal(t, "RS256", auth.Status.SigningKey.Algorithm)
+	require.Equal(t, now, auth.Status.SigningKey.LastRotation.Time)
+
+	// Nothing change before the interval
+	firstKey := current
+	current, previous, err = rotateSigningKeys(auth, current, previous, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, firstKey, current)
+	require.Empty(t, previous)
+
+	// The key is rotated, the previous one is kept during the grace period
+	rotatedAt := now.Add(24 * time.Hour)
+	current, previous, err = rotateSigningKeys(auth, current, previous, rotatedAt)
+	require.NoError(t, err)
+	require.NotEqual(t, firstKey, current)
+	require.Equal(t, firstKey, previous)
+	require.Equal(t, rotatedAt, auth.Status.SigningKey.LastRotation.Time)
+	require.Equal(t, rotatedAt.Add(time.Hour), auth.Status.SigningKey.PreviousKeyExpiresAt.Time)
+
+	// The previous key is retired after the grace period
+	secondKey := current
+	current, previous, err = rotateSigningKeys(auth, current, previous, rotatedAt.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, secondKey, current)
+	require.Empty(t, previous)
+	require.Nil(t, auth.Status.SigningKey.PreviousKeyExpiresAt)
+
+	// Changing the algorithm rotates the key
+	auth.Spec.SigningKeyAlgorithm = "ES256"
+	current, previous, err = rotateSigningKeys(auth, current, previous, rotatedAt.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, secondKey, previous)
+	block, _ = pem.Decode([]byte(current))
+	require.NotNil(t, block)
+	_, err = x509.ParseECPrivateKey(block.Bytes)
+	require.NoError(t, err)
+	require.Equal(t, "ES256", auth.Status.SigningKey.Algorithm)
+
+	// Without rotation, the key is kept and the previous one is retired
+	auth.Spec.SigningKeyRotation = nil
+	thirdKey := current
+	current, previous, err = rotateSigningKeys(auth, current, previous, rotatedAt.Add(100*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, thirdKey, current)
+	require.Empty(t, previous)
+
+	// The grace period must be lower than the interval
+	auth.Spec.SigningKeyRotation = &v1beta1.SigningKeyRotation{
+		Interval:    metav1.Duration{Duration: time.Hour},
+		GracePeriod: metav1.Duration{Duration: time.Hour},
+	}
+	_, _, err = rotateSigningKeys(auth, current, previous, now)
+	require.Error(t, err)
+}
+
+func TestIsSigningKeyGenerated(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		spec     v1beta1.AuthSpec
+		expected bool
+	}
+	for _, tc := range []testCase{
+		{name: "nothing configured"},
+		{name: "algorithm", spec: v1beta1.AuthSpec{SigningKeyAlgorithm: "RS256"}, expected: true},
+		{name: "rotation", spec: v1beta1.AuthSpec{
+			SigningKeyRotation: &v1beta1.SigningKeyRotation{Interval: metav1.Duration{Duration: time.Hour}},
+		}, expected: true},
+		{name: "provided key", spec: v1beta1.AuthSpec{SigningKey: "key", SigningKeyAlgorithm: "RS256"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, isSigningKeyGenerated(&v1beta1.Auth{Spec: tc.spec}))
+		})
+	}
+}
+
+func TestCheckSigningKeyVersion(t *testing.T) {
+	t.Parallel()
+
+	withGracePeriod := &v1beta1.Auth{
+		Spec: v1beta1.AuthSpec{
+			SigningKeyRotation: &v1beta1.SigningKeyRotation{
+				Interval:    metav1.Duration{Duration: 24 * time.Hour},
+				GracePeriod: metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	withES256 := &v1beta1.Auth{
+		Spec: v1beta1.AuthSpec{
+			SigningKeyAlgorithm: "ES256",
+		},
+	}
+	withoutGracePeriod := &v1beta1.Auth{
+		Spec: v1beta1.AuthSpec{
+			SigningKeyRotation: &v1beta1.SigningKeyRotation{
+				Interval: metav1.Duration{Duration: 24 * time.Hour},
+			},
+		},
+	}
+
+	type testCase struct {
+		name        string
+		auth        *v1beta1.Auth
+		version     string
+		expectError bool
+	}
+	for _, tc := range []testCase{
+		{name: "grace period on recent version", auth: withGracePeriod, version: "v3.1.0"},
+		{name: "grace period on branch", auth: withGracePeriod, version: "main"},
+		{name: "grace period on old version", auth: withGracePeriod, version: "v2.0.0", expectError: true},
+		{name: "no grace period on old version", auth: withoutGracePeriod, version: "v2.0.0"},
+		{name: "no rotation on old version", auth: &v1beta1.Auth{}, version: "v2.0.0"},
+		{name: "ES256 on recent version", auth: withES256, version: "v3.1.0"},
+		{name: "ES256 on old version", auth: withES256, version: "v2.0.0", expectError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkSigningKeyVersion(tc.auth, tc.version)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package auths
+
+import "golang.org/x/mod/semver"
+
+// Minimal versions of the auth server reading the options configured by the operator.
+// The options are not configured on older versions, as they would be silently ignored.
+const (
+	// delegatedOIDCOptionsVersion is the first version reading DELEGATED_SCOPES and DELEGATED_EMAIL_CLAIM
+	delegatedOIDCOptionsVersion = "v3.1.0-alpha"
+	// clientSecretsVersion is the first version reading the secrets list of the clients,
+	// allowing to accept the previous secret of a client during the overlap period of a rotation
+	clientSecretsVersion = "v3.1.0-alpha"
+	// previousSigningKeyVersion is the first version publishing PREVIOUS_SIGNING_KEY in its JWKS
+	previousSigningKeyVersion = "v3.1.0-alpha"
+	// es256SigningKeyVersion is the first version accepting an ECDSA signing key
+	es256SigningKeyVersion = "v3.1.0-alpha"
+)
+
+// isSupported returns whether the auth version reads an option available from minimalVersion.
+// Versions which are not semver, like branches, are assumed to be up-to-date.
+func isSupported(version, minimalVersion string) bool {
+	return !semver.IsValid(version) || semver.Compare(version, minimalVersion) >= 0
+}
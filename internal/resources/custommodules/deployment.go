@@ -0,0 +1,158 @@
+package custommodules
+
+import (
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/applications"
+	"github.com/formancehq/operator/v3/internal/resources/authclients"
+	"github.com/formancehq/operator/v3/internal/resources/brokers"
+	"github.com/formancehq/operator/v3/internal/resources/brokertopics"
+	"github.com/formancehq/operator/v3/internal/resources/databases"
+	"github.com/formancehq/operator/v3/internal/resources/gateways"
+	"github.com/formancehq/operator/v3/internal/resources/registries"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+)
+
+func brokerEnvVars(ctx core.Context, stack *v1beta1.Stack, module *v1beta1.CustomModule, consumer *v1beta1.BrokerConsumer) ([]v1.EnvVar, error) {
+	if module.Spec.Broker == nil {
+		return nil, nil
+	}
+
+	var publishTopic *v1beta1.BrokerTopic
+	if module.Spec.Broker.Publish {
+		topic, err := brokertopics.Find(ctx, stack, module.Spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		if topic != nil && topic.Status.Ready {
+			publishTopic = topic
+		}
+	}
+	if consumer == nil && publishTopic == nil {
+		return nil, nil
+	}
+
+	broker := &v1beta1.Broker{}
+	if err := ctx.GetClient().Get(ctx, core.GetResourceName(stack.Name), broker); err != nil {
+		return nil, err
+	}
+	if !broker.Status.Ready {
+		return nil, core.NewPendingError().WithMessage("broker not ready")
+	}
+
+	env, err := brokers.GetBrokerEnvVars(ctx, broker.Status.URI, stack.Name, module.Spec.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if consumer != nil {
+		topics, err := brokers.GetTopicsEnvVars(ctx, stack, "BROKER_TOPICS", consumer.Spec.Services...)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, topics...)
+	}
+
+	if publishTopic != nil {
+		env = append(env, brokers.GetPublisherEnvVars(stack, broker, module.Spec.Name)...)
+	}
+
+	return env, nil
+}
+
+func createDeployment(ctx core.Context, stack *v1beta1.Stack, module *v1beta1.CustomModule,
+	database *v1beta1.Database, consumer *v1beta1.BrokerConsumer, authClient *v1beta1.AuthClient) error {
+
+	env := make([]v1.EnvVar, 0)
+	otlpEnv, err := settings.GetOTELEnvVars(ctx, stack.Name, module.Spec.Name, " ")
+	if err != nil {
+		return err
+	}
+	env = append(env, otlpEnv...)
+
+	gatewayEnv, err := gateways.EnvVarsIfEnabled(ctx, stack.Name)
+	if err != nil {
+		return err
+	}
+	env = append(env, gatewayEnv...)
+	env = append(env, core.GetDevEnvVars(stack, module)...)
+
+	if database != nil {
+		postgresEnvVars, err := databases.GetPostgresEnvVars(ctx, stack, database)
+		if err != nil {
+			return err
+		}
+		env = append(env, postgresEnvVars...)
+	}
+
+	brokerEnv, err := brokerEnvVars(ctx, stack, module, consumer)
+	if err != nil {
+		return err
+	}
+	env = append(env, brokerEnv...)
+
+	if authClient != nil {
+		env = append(env, authclients.GetEnvVars(authClient)...)
+	}
+
+	// Variables defined on the module come last to allow referencing the previous ones
+	env = append(env, module.Spec.Env...)
+
+	imageConfiguration, err := registries.GetImageConfiguration(ctx, stack.Name, module.Spec.Image)
+	if err != nil {
+		return errors.Wrap(err, "resolving image")
+	}
+
+	serviceAccountName, err := settings.GetAWSServiceAccount(ctx, stack.Name)
+	if err != nil {
+		return err
+	}
+
+	port := applications.StandardHTTPPort()
+	if module.Spec.Port != 0 {
+		port.ContainerPort = module.Spec.Port
+	}
+
+	container := v1.Container{
+		Name:    module.Spec.Name,
+		Image:   imageConfiguration.GetFullImageName(),
+		Command: module.Spec.Command,
+		Args:    module.Spec.Args,
+		Env:     env,
+		Ports:   []v1.ContainerPort{port},
+	}
+	if module.Spec.HealthCheckPath != "" {
+		container.LivenessProbe = applications.DefaultLiveness(port.Name, applications.WithProbePath(module.Spec.HealthCheckPath))
+		container.ReadinessProbe = applications.DefaultReadiness(port.Name, applications.WithProbePath(module.Spec.HealthCheckPath))
+	}
+
+	annotations, err := authclients.GetAnnotations(ctx, authClient)
+	if err != nil {
+		return err
+	}
+
+	return applications.
+		New(module, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: module.Spec.Name,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: annotations,
+					},
+					Spec: v1.PodSpec{
+						ServiceAccountName: serviceAccountName,
+						ImagePullSecrets:   imageConfiguration.PullSecrets,
+						Containers:         []v1.Container{container},
+					},
+				},
+			},
+		}).
+		Install(ctx)
+}
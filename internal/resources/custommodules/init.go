@@ -0,0 +1,111 @@
+package custommodules
+
+import (
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/authclients"
+	"github.com/formancehq/operator/v3/internal/resources/brokerconsumers"
+	"github.com/formancehq/operator/v3/internal/resources/brokers"
+	"github.com/formancehq/operator/v3/internal/resources/databases"
+	"github.com/formancehq/operator/v3/internal/resources/gatewayhttpapis"
+)
+
+//+kubebuilder:rbac:groups=formance.com,resources=custommodules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=custommodules/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=custommodules/finalizers,verbs=update
+
+func Reconcile(ctx Context, stack *v1beta1.Stack, module *v1beta1.CustomModule) error {
+	if err := checkName(ctx, module); err != nil {
+		return err
+	}
+
+	var database *v1beta1.Database
+	if module.Spec.Database {
+		var err error
+		database, err = databases.CreateForService(ctx, stack, module, module.Spec.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	var consumer *v1beta1.BrokerConsumer
+	if module.Spec.Broker != nil && len(module.Spec.Broker.Consumes) > 0 {
+		var err error
+		consumer, err = brokerconsumers.Create(ctx, module, "", module.Spec.Broker.Consumes...)
+		if err != nil {
+			return err
+		}
+	}
+
+	var authClient *v1beta1.AuthClient
+	if module.Spec.AuthClient != nil {
+		var err error
+		authClient, err = authclients.Create(ctx, stack, module, module.Spec.Name,
+			authclients.WithScopes(module.Spec.AuthClient.Scopes...))
+		if err != nil {
+			return err
+		}
+	}
+
+	if module.Spec.Gateway != nil {
+		options := make([]gatewayhttpapis.Option, 0)
+		if len(module.Spec.Gateway.Rules) > 0 {
+			options = append(options, gatewayhttpapis.WithRules(module.Spec.Gateway.Rules...))
+		}
+		if module.Spec.Gateway.HealthCheckEndpoint != "" {
+			options = append(options, gatewayhttpapis.WithHealthCheckEndpoint(module.Spec.Gateway.HealthCheckEndpoint))
+		}
+		if err := gatewayhttpapis.CreateForService(ctx, module, module.Spec.Name, options...); err != nil {
+			return err
+		}
+	}
+
+	if database != nil && !database.Status.Ready {
+		return NewPendingError().WithMessage("database not ready").OnlyOnWatchEvent()
+	}
+	if consumer != nil && !consumer.Status.Ready {
+		return NewPendingError().WithMessage("broker consumer not ready")
+	}
+
+	if err := createDeployment(ctx, stack, module, database, consumer, authClient); err != nil {
+		return errors.Wrap(err, "creating deployment")
+	}
+
+	return nil
+}
+
+func init() {
+	Init(
+		WithUnversionedModuleReconciler(Reconcile,
+			WithOwn[*v1beta1.CustomModule](&appsv1.Deployment{}),
+			WithOwn[*v1beta1.CustomModule](&v1beta1.Database{}),
+			WithOwn[*v1beta1.CustomModule](&v1beta1.BrokerConsumer{}),
+			WithOwn[*v1beta1.CustomModule](&v1beta1.AuthClient{}),
+			WithOwn[*v1beta1.CustomModule](&v1beta1.GatewayHTTPAPI{}),
+			WithOwn[*v1beta1.CustomModule](&v1beta1.ResourceReference{}),
+			WithWatchSettings[*v1beta1.CustomModule](),
+			WithWatchDependency[*v1beta1.CustomModule](&v1beta1.Auth{}),
+			// A module waiting for its name is reconciled when the module using it is deleted
+			WithWatchDependency[*v1beta1.CustomModule](&v1beta1.CustomModule{}),
+			WithWatch[*v1beta1.CustomModule, *v1beta1.BrokerTopic](func(ctx Context, topic *v1beta1.BrokerTopic) []reconcile.Request {
+				modules := make([]*v1beta1.CustomModule, 0)
+				if err := GetAllStackDependencies(ctx, topic.Spec.Stack, &modules); err != nil {
+					return nil
+				}
+
+				ret := make([]reconcile.Request, 0)
+				for _, module := range modules {
+					if module.Spec.Name == topic.Spec.Service {
+						ret = append(ret, MapObjectToReconcileRequests(module)...)
+					}
+				}
+				return ret
+			}),
+			brokers.Watch[*v1beta1.CustomModule](),
+		),
+	)
+}
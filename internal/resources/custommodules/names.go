@@ -0,0 +1,79 @@
+package custommodules
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+)
+
+// auxiliaryNames are the names of the deployments and services created by the modules of the operator,
+// in addition to the names of the modules
+var auxiliaryNames = []string{
+	"benthos",
+	"ledger-worker",
+	"payments-connectors",
+	"payments-read",
+	"transactionplane-worker",
+}
+
+// builtinNames returns the names used by the modules of the operator for their deployments, services and databases
+func builtinNames(scheme *runtime.Scheme) map[string]struct{} {
+	ret := map[string]struct{}{}
+	for gvk := range scheme.AllKnownTypes() {
+		if gvk.GroupVersion() != v1beta1.GroupVersion {
+			continue
+		}
+		object, err := scheme.New(gvk)
+		if err != nil {
+			continue
+		}
+		if _, ok := object.(v1beta1.Module); !ok {
+			continue
+		}
+		if _, ok := object.(*v1beta1.CustomModule); ok {
+			continue
+		}
+		ret[strings.ToLower(gvk.Kind)] = struct{}{}
+	}
+	for _, name := range auxiliaryNames {
+		ret[name] = struct{}{}
+	}
+
+	return ret
+}
+
+// findConflictingModule returns the custom module using the same name in the stack, if it has been created first.
+// The oldest module keeps the name, so the resources of a running module are never taken over by a new one.
+func findConflictingModule(module *v1beta1.CustomModule, modules []*v1beta1.CustomModule) *v1beta1.CustomModule {
+	for _, other := range modules {
+		if other.Name == module.Name || other.Spec.Name != module.Spec.Name {
+			continue
+		}
+		if other.CreationTimestamp.Before(&module.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&module.CreationTimestamp) && other.Name < module.Name) {
+			return other
+		}
+	}
+	return nil
+}
+
+// checkName rejects the names colliding with a module of the operator, or with another custom module of the stack,
+// as the deployment, the service and the database of the module would be shared.
+func checkName(ctx Context, module *v1beta1.CustomModule) error {
+	if _, ok := builtinNames(ctx.GetScheme())[module.Spec.Name]; ok {
+		return NewApplicationError().WithMessage("name '%s' is used by a module of the operator", module.Spec.Name)
+	}
+
+	modules := make([]*v1beta1.CustomModule, 0)
+	if err := GetAllStackDependencies(ctx, module.Spec.Stack, &modules); err != nil {
+		return err
+	}
+	if other := findConflictingModule(module, modules); other != nil {
+		return NewApplicationError().WithMessage("name '%s' is already used by the custom module '%s'", module.Spec.Name, other.Name)
+	}
+
+	return nil
+}
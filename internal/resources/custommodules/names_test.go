@@ -0,0 +1,54 @@
+package custommodules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestBuiltinNames(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	names := builtinNames(scheme)
+	for _, name := range []string{"ledger", "ledger-worker", "payments", "payments-connectors", "gateway", "auth", "wallets"} {
+		require.Contains(t, names, name)
+	}
+	require.NotContains(t, names, "custommodule")
+	require.NotContains(t, names, "my-module")
+}
+
+func TestFindConflictingModule(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	newModule := func(name, moduleName string, createdAt time.Time) *v1beta1.CustomModule {
+		return &v1beta1.CustomModule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.Time{Time: createdAt},
+			},
+			Spec: v1beta1.CustomModuleSpec{
+				Name: moduleName,
+			},
+		}
+	}
+	first := newModule("first", "exporter", now)
+	second := newModule("second", "exporter", now.Add(time.Minute))
+	sameTime := newModule("other", "exporter", now)
+	unrelated := newModule("unrelated", "importer", now.Add(-time.Minute))
+	modules := []*v1beta1.CustomModule{first, second, sameTime, unrelated}
+
+	// The oldest module keeps the name
+	require.Nil(t, findConflictingModule(first, modules))
+	require.Equal(t, first, findConflictingModule(second, modules))
+	require.Equal(t, first, findConflictingModule(sameTime, modules))
+	require.Nil(t, findConflictingModule(unrelated, modules))
+}
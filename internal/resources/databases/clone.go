@@ -0,0 +1,117 @@
+package databases
+
+import (
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/jobs"
+	"github.com/formancehq/operator/v3/internal/resources/registries"
+	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+)
+
+// cloneDatabaseScript copies the source database into the created database.
+// Objects are dropped before being recreated, so the job can be safely retried after a partial copy.
+const cloneDatabaseScript = `set -e -o pipefail
+pg_dump --clean --if-exists --no-owner --no-privileges "$SOURCE_POSTGRES_URI" | psql --quiet --set ON_ERROR_STOP=1 "$POSTGRES_URI"
+`
+
+// cloneDatabase copies the content of the database referenced by .spec.cloneFrom using a job
+func cloneDatabase(ctx core.Context, stack *v1beta1.Stack, database *v1beta1.Database) error {
+	source := &v1beta1.Database{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: database.Spec.CloneFrom,
+	}, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return core.NewApplicationError().WithMessage("source database '%s' not found", database.Spec.CloneFrom)
+		}
+		return err
+	}
+	if !source.Status.Ready || source.Status.URI == nil {
+		return core.NewPendingError().WithMessage("source database '%s' not ready", source.Name)
+	}
+
+	sourceStack := &v1beta1.Stack{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: source.Spec.Stack,
+	}, sourceStack); err != nil {
+		return err
+	}
+
+	for _, stackName := range []string{stack.Name, sourceStack.Name} {
+		awsRole, err := settings.GetAWSServiceAccount(ctx, stackName)
+		if err != nil {
+			return err
+		}
+		if awsRole != "" {
+			return core.NewApplicationError().WithMessage("database copy is not supported with AWS IAM authentication")
+		}
+	}
+
+	// The job is run in the namespace of the stack, so the secret of the source database must be replicated there
+	// if it is not the one already used by the database
+	annotations := make(map[string]string)
+	if secret := source.Status.URI.Query().Get("secret"); secret != "" && secret != database.Status.URI.Query().Get("secret") {
+		reference, err := resourcereferences.Create(ctx, database, "clone-postgres", secret, &v1.Secret{})
+		if err != nil {
+			return err
+		}
+		annotations["source-secret-hash"] = reference.Status.Hash
+	} else if err := resourcereferences.Delete(ctx, database, "clone-postgres"); err != nil {
+		return err
+	}
+
+	sourceEnv, err := GetPostgresEnvVars(ctx, sourceStack, source)
+	if err != nil {
+		return err
+	}
+
+	env, err := GetPostgresEnvVars(ctx, stack, database)
+	if err != nil {
+		return err
+	}
+
+	postgresImage, err := registries.GetPostgresImage(ctx, stack)
+	if err != nil {
+		return err
+	}
+
+	return jobs.Handle(ctx, database, "clone-database", v1.Container{
+		Name:  "clone-database",
+		Image: postgresImage.GetFullImageName(),
+		// The script is not run with core.ShellScript, as tracing the commands would print the credentials
+		Args: []string{"sh", "-c", cloneDatabaseScript},
+		Env:  append(env, prefixEnvVars("SOURCE_", sourceEnv)...),
+	},
+		jobs.Mutator(core.WithAnnotations[*batchv1.Job](annotations)),
+		jobs.WithImagePullSecrets(postgresImage.PullSecrets),
+		jobs.WatchedByOwner(),
+	)
+}
+
+// prefixEnvVars renames the env vars with the given prefix, including the references between them
+func prefixEnvVars(prefix string, env []v1.EnvVar) []v1.EnvVar {
+	replacements := make([]string, 0, 2*len(env))
+	for _, envVar := range env {
+		replacements = append(replacements,
+			core.EnvVarPlaceholder(envVar.Name),
+			core.EnvVarPlaceholder(prefix+envVar.Name),
+		)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	ret := make([]v1.EnvVar, 0, len(env))
+	for _, envVar := range env {
+		envVar.Name = prefix + envVar.Name
+		envVar.Value = replacer.Replace(envVar.Value)
+		ret = append(ret, envVar)
+	}
+
+	return ret
+}
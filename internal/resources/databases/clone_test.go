@@ -0,0 +1,26 @@
+package databases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/formancehq/operator/v3/internal/core"
+)
+
+func TestPrefixEnvVars(t *testing.T) {
+	t.Parallel()
+
+	ret := prefixEnvVars("SOURCE_", []v1.EnvVar{
+		core.Env("POSTGRES_HOST", "localhost"),
+		core.EnvFromSecret("POSTGRES_PASSWORD", "postgres", "password"),
+		core.Env("POSTGRES_URI", core.ComputeEnvVar("postgresql://%s@%s", "POSTGRES_PASSWORD", "POSTGRES_HOST")),
+	})
+
+	require.Equal(t, []v1.EnvVar{
+		core.Env("SOURCE_POSTGRES_HOST", "localhost"),
+		core.EnvFromSecret("SOURCE_POSTGRES_PASSWORD", "postgres", "password"),
+		core.Env("SOURCE_POSTGRES_URI", "postgresql://$(SOURCE_POSTGRES_PASSWORD)@$(SOURCE_POSTGRES_HOST)"),
+	}, ret)
+}
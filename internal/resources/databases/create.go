@@ -16,6 +16,14 @@ func Create(ctx core.Context, stack *v1beta1.Stack, owner interface {
 	v1beta1.Object
 	IsDebug() bool
 }) (*v1beta1.Database, error) {
+	return CreateForService(ctx, stack, owner, strings.ToLower(owner.GetObjectKind().GroupVersionKind().Kind))
+}
+
+// CreateForService creates the database of a service, when the kind of the owner does not identify the service
+func CreateForService(ctx core.Context, stack *v1beta1.Stack, owner interface {
+	v1beta1.Object
+	IsDebug() bool
+}, serviceName string) (*v1beta1.Database, error) {
 	condition := v1beta1.Condition{
 		Type:               "DatabaseReady",
 		ObservedGeneration: owner.GetGeneration(),
@@ -25,7 +33,6 @@ func Create(ctx core.Context, stack *v1beta1.Stack, owner interface {
 		owner.GetConditions().AppendOrReplace(condition, v1beta1.ConditionTypeMatch("DatabaseReady"))
 	}()
 
-	serviceName := strings.ToLower(owner.GetObjectKind().GroupVersionKind().Kind)
 	database, _, err := core.CreateOrUpdate[*v1beta1.Database](ctx, types.NamespacedName{
 		Name: core.GetObjectName(stack.Name, serviceName),
 	},
@@ -99,6 +99,12 @@ func Reconcile(ctx core.Context, stack *v1beta1.Stack, database *v1beta1.Databas
 		if err := handleDatabaseJob(ctx, stack, database, "create-database", "db", "create"); err != nil {
 			return err
 		}
+
+		if database.Spec.CloneFrom != "" {
+			if err := cloneDatabase(ctx, stack, database); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -178,6 +184,7 @@ func handleDatabaseJob(ctx core.Context, stack *v1beta1.Stack, database *v1beta1
 		jobs.Mutator(core.WithAnnotations[*batchv1.Job](annotations)),
 		jobs.WithServiceAccount(serviceAccountName),
 		jobs.WithImagePullSecrets(operatorUtilsImage.PullSecrets),
+		jobs.WatchedByOwner(),
 	)
 }
 
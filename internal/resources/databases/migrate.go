@@ -42,6 +42,8 @@ func Migrate(
 		append(options,
 			jobs.WithImagePullSecrets(imageConfiguration.PullSecrets),
 			jobs.WithServiceAccount(serviceAccountName),
+			// The modules own their migration jobs
+			jobs.WatchedByOwner(),
 		)...,
 	)
 }
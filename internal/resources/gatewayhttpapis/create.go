@@ -1,24 +1,31 @@
 package gatewayhttpapis
 
 import (
+	"slices"
+
 	"k8s.io/apimachinery/pkg/types"
 
 	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	"github.com/formancehq/operator/v3/internal/core"
 )
 
-type option func(spec *v1beta1.GatewayHTTPAPI)
+type Option func(spec *v1beta1.GatewayHTTPAPI)
 
-var defaultOptions = []option{
+var defaultOptions = []Option{
 	WithRules(RuleSecured()),
 }
 
-func Create(ctx core.Context, owner v1beta1.Module, options ...option) error {
-	objectName := core.LowerCaseKind(ctx, owner)
+func Create(ctx core.Context, owner v1beta1.Module, options ...Option) error {
+	return CreateForService(ctx, owner, core.LowerCaseKind(ctx, owner), options...)
+}
+
+// CreateForService exposes a service on the gateway, when the kind of the owner does not identify the service
+func CreateForService(ctx core.Context, owner v1beta1.Module, objectName string, options ...Option) error {
 	_, _, err := core.CreateOrUpdate[*v1beta1.GatewayHTTPAPI](ctx, types.NamespacedName{
-		Name: core.GetObjectName(owner.GetStack(), core.LowerCaseKind(ctx, owner)),
+		Name: core.GetObjectName(owner.GetStack(), objectName),
 	},
 		func(t *v1beta1.GatewayHTTPAPI) error {
+			previousRules := t.Spec.Rules
 			t.Spec = v1beta1.GatewayHTTPAPISpec{
 				StackDependency: v1beta1.StackDependency{
 					Stack: owner.GetStack(),
@@ -28,6 +35,7 @@ func Create(ctx core.Context, owner v1beta1.Module, options ...option) error {
 			for _, option := range append(defaultOptions, options...) {
 				option(t)
 			}
+			t.Spec.Rules = keepAuthorizations(t.Spec.Rules, previousRules)
 
 			return nil
 		},
@@ -36,6 +44,27 @@ func Create(ctx core.Context, owner v1beta1.Module, options ...option) error {
 	return err
 }
 
+// keepAuthorizations keeps the scopes and claims configured by the users on the rules.
+// They are copied on the rule of the same path, and the rules with scopes or claims added on other paths are kept.
+func keepAuthorizations(rules, previousRules []v1beta1.GatewayHTTPAPIRule) []v1beta1.GatewayHTTPAPIRule {
+	ret := slices.Clone(rules)
+	for _, previous := range previousRules {
+		if len(previous.Scopes) == 0 && len(previous.Claims) == 0 {
+			continue
+		}
+		index := slices.IndexFunc(ret, func(rule v1beta1.GatewayHTTPAPIRule) bool {
+			return rule.Path == previous.Path
+		})
+		if index == -1 {
+			ret = append(ret, previous)
+			continue
+		}
+		ret[index].Scopes = previous.Scopes
+		ret[index].Claims = previous.Claims
+	}
+	return ret
+}
+
 func WithRules(rules ...v1beta1.GatewayHTTPAPIRule) func(httpapi *v1beta1.GatewayHTTPAPI) {
 	return func(httpapi *v1beta1.GatewayHTTPAPI) {
 		httpapi.Spec.Rules = rules
@@ -0,0 +1,31 @@
+package gatewayhttpapis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestKeepAuthorizations(t *testing.T) {
+	t.Parallel()
+
+	rules := []v1beta1.GatewayHTTPAPIRule{RuleSecured()}
+	previousRules := []v1beta1.GatewayHTTPAPIRule{
+		{Scopes: []string{"ledger:read"}},
+		{Path: "/v2/admin", Claims: []v1beta1.GatewayHTTPAPIClaimMatcher{{
+			Claim:  "org",
+			Values: []string{"formance"},
+		}}},
+		// Rules without authorization are managed by the module
+		{Path: "/_info"},
+	}
+
+	require.Equal(t, []v1beta1.GatewayHTTPAPIRule{
+		{Scopes: []string{"ledger:read"}},
+		previousRules[1],
+	}, keepAuthorizations(rules, previousRules))
+	// The rules of the module are not modified
+	require.Equal(t, []v1beta1.GatewayHTTPAPIRule{RuleSecured()}, rules)
+}
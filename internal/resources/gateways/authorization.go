@@ -0,0 +1,134 @@
+package gateways
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	collectionutils "github.com/formancehq/go-libs/v5/pkg/types/collections"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+const (
+	// ConditionTypeAuthorizationEnforced reports if the scopes and claims required by the rules are enforced
+	ConditionTypeAuthorizationEnforced = "AuthorizationEnforced"
+
+	// authAPIName is the name of the http api of the auth server, whose keys validate the tokens
+	authAPIName = "auth"
+)
+
+// caddyRule is a rule of an http api, with the authorization to enforce on the gateway, if any
+type caddyRule struct {
+	v1beta1.GatewayHTTPAPIRule
+	Authorization *caddyAuthorization
+}
+
+type caddyAuthorization struct {
+	// MetaClaims are the claims of the token exposed as placeholders by the jwtauth directive
+	MetaClaims []string
+	// Expression is the CEL expression matching the authorized requests
+	Expression string
+}
+
+type caddyService struct {
+	v1beta1.GatewayHTTPAPISpec
+	Rules []caddyRule
+}
+
+// toCaddyServices computes the services to render in the Caddyfile.
+// When enableAuthorization is true, the rules requiring scopes or claims are rendered with their authorization,
+// and are denied if it cannot be enforced.
+func toCaddyServices(httpAPIs []*v1beta1.GatewayHTTPAPI, enableAuthorization bool) []caddyService {
+	return collectionutils.Map(httpAPIs, func(from *v1beta1.GatewayHTTPAPI) caddyService {
+		return caddyService{
+			GatewayHTTPAPISpec: from.Spec,
+			Rules: collectionutils.Map(from.Spec.Rules, func(rule v1beta1.GatewayHTTPAPIRule) caddyRule {
+				ret := caddyRule{
+					GatewayHTTPAPIRule: rule,
+				}
+				if enableAuthorization {
+					ret.Authorization = ruleAuthorization(rule)
+				}
+				return ret
+			}),
+		}
+	})
+}
+
+// resolveJWKSURL returns the url of the keys validating the tokens, or an empty string if the scopes and claims
+// required by the rules can not be enforced, in which case the routes requiring them are denied.
+// The result is reported on the AuthorizationEnforced condition.
+// The jwtauth directive is provided by a third-party caddy module (github.com/ggicci/caddy-jwt), which must
+// be declared available in the gateway image using the setting gateway.caddyfile.jwtauth.enabled.
+func resolveJWKSURL(gateway *v1beta1.Gateway, httpAPIs []*v1beta1.GatewayHTTPAPI, hasAuth, jwtAuthAvailable bool) string {
+	requireAuthorization := slices.ContainsFunc(httpAPIs, func(httpAPI *v1beta1.GatewayHTTPAPI) bool {
+		return slices.ContainsFunc(httpAPI.Spec.Rules, func(rule v1beta1.GatewayHTTPAPIRule) bool {
+			return ruleAuthorization(rule) != nil
+		})
+	})
+	if !requireAuthorization || !hasAuth {
+		// Without auth module on the stack, nothing is enforced
+		gateway.GetConditions().Delete(v1beta1.ConditionTypeMatch(ConditionTypeAuthorizationEnforced))
+		return ""
+	}
+
+	condition := v1beta1.NewCondition(ConditionTypeAuthorizationEnforced, gateway.Generation).
+		SetReason("Enforced").
+		SetMessage("Scopes and claims required by the rules are enforced")
+	defer func() {
+		gateway.GetConditions().AppendOrReplace(*condition, v1beta1.ConditionTypeMatch(ConditionTypeAuthorizationEnforced))
+	}()
+
+	if !jwtAuthAvailable {
+		condition.Fail("Rules require scopes or claims, but the gateway image is not declared to provide the jwtauth " +
+			"caddy module, their routes are denied: set gateway.caddyfile.jwtauth.enabled once the image provides it").
+			SetReason("JWTAuthUnavailable")
+		return ""
+	}
+
+	authAPIIndex := slices.IndexFunc(httpAPIs, func(httpAPI *v1beta1.GatewayHTTPAPI) bool {
+		return httpAPI.Spec.Name == authAPIName
+	})
+	if authAPIIndex == -1 {
+		condition.Fail("Waiting for the http api of the auth server, the routes requiring scopes or claims are denied").
+			SetReason("AuthAPINotFound")
+		return ""
+	}
+
+	// The http apis are served on the port 8080 of the service named after them
+	return fmt.Sprintf("http://%s:8080/keys", httpAPIs[authAPIIndex].Spec.Name)
+}
+
+// ruleAuthorization computes the CEL expression validating the scopes and the claims of the token of a request.
+// The scope claim is a space separated list of scopes.
+func ruleAuthorization(rule v1beta1.GatewayHTTPAPIRule) *caddyAuthorization {
+	if len(rule.Scopes) == 0 && len(rule.Claims) == 0 {
+		return nil
+	}
+
+	ret := &caddyAuthorization{}
+	conditions := make([]string, 0)
+	if len(rule.Scopes) > 0 {
+		ret.MetaClaims = append(ret.MetaClaims, "scope")
+		for _, scope := range rule.Scopes {
+			conditions = append(conditions, fmt.Sprintf("{http.auth.user.scope}.matches(%s)",
+				celString("(^| )"+regexp.QuoteMeta(scope)+"( |$)")))
+		}
+	}
+	for _, matcher := range rule.Claims {
+		if !slices.Contains(ret.MetaClaims, matcher.Claim) {
+			ret.MetaClaims = append(ret.MetaClaims, matcher.Claim)
+		}
+		conditions = append(conditions, fmt.Sprintf("{http.auth.user.%s} in [%s]", matcher.Claim,
+			strings.Join(collectionutils.Map(matcher.Values, celString), ", ")))
+	}
+	ret.Expression = strings.Join(conditions, " && ")
+
+	return ret
+}
+
+func celString(v string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "`", "").Replace(v) + "'"
+}
@@ -0,0 +1,175 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestRuleAuthorization(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		rule     v1beta1.GatewayHTTPAPIRule
+		expected *caddyAuthorization
+	}
+	for _, tc := range []testCase{
+		{
+			name: "no scopes nor claims",
+			rule: v1beta1.GatewayHTTPAPIRule{
+				Path:    "/",
+				Secured: true,
+			},
+		},
+		{
+			name: "scopes",
+			rule: v1beta1.GatewayHTTPAPIRule{
+				Path:   "/",
+				Scopes: []string{"ledger:read", "ledger.write"},
+			},
+			expected: &caddyAuthorization{
+				MetaClaims: []string{"scope"},
+				Expression: `{http.auth.user.scope}.matches('(^| )ledger:read( |$)') && ` +
+					`{http.auth.user.scope}.matches('(^| )ledger\\.write( |$)')`,
+			},
+		},
+		{
+			name: "scopes and claims",
+			rule: v1beta1.GatewayHTTPAPIRule{
+				Path:   "/",
+				Scopes: []string{"admin"},
+				Claims: []v1beta1.GatewayHTTPAPIClaimMatcher{
+					{
+						Claim:  "org",
+						Values: []string{"formance", "o'reilly"},
+					},
+					{
+						Claim:  "scope",
+						Values: []string{"admin"},
+					},
+				},
+			},
+			expected: &caddyAuthorization{
+				MetaClaims: []string{"scope", "org"},
+				Expression: `{http.auth.user.scope}.matches('(^| )admin( |$)') && ` +
+					`{http.auth.user.org} in ['formance', 'o\'reilly'] && ` +
+					`{http.auth.user.scope} in ['admin']`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, ruleAuthorization(tc.rule))
+		})
+	}
+}
+
+func TestToCaddyServices(t *testing.T) {
+	t.Parallel()
+
+	httpAPIs := []*v1beta1.GatewayHTTPAPI{{
+		Spec: v1beta1.GatewayHTTPAPISpec{
+			Name: "ledger",
+			Rules: []v1beta1.GatewayHTTPAPIRule{
+				{Path: "/_info"},
+				{Path: "/", Scopes: []string{"ledger:read"}},
+			},
+		},
+	}}
+
+	services := toCaddyServices(httpAPIs, true)
+	require.Len(t, services, 1)
+	require.Equal(t, "ledger", services[0].Name)
+	require.Nil(t, services[0].Rules[0].Authorization)
+	require.NotNil(t, services[0].Rules[1].Authorization)
+
+	services = toCaddyServices(httpAPIs, false)
+	require.Nil(t, services[0].Rules[1].Authorization)
+}
+
+func TestResolveJWKSURL(t *testing.T) {
+	t.Parallel()
+
+	ledgerAPI := &v1beta1.GatewayHTTPAPI{
+		Spec: v1beta1.GatewayHTTPAPISpec{
+			Name: "ledger",
+			Rules: []v1beta1.GatewayHTTPAPIRule{
+				{Path: "/", Scopes: []string{"ledger:read"}},
+			},
+		},
+	}
+	authAPI := &v1beta1.GatewayHTTPAPI{
+		Spec: v1beta1.GatewayHTTPAPISpec{
+			Name:  "auth",
+			Rules: []v1beta1.GatewayHTTPAPIRule{{Path: "/"}},
+		},
+	}
+
+	type testCase struct {
+		name              string
+		httpAPIs          []*v1beta1.GatewayHTTPAPI
+		hasAuth           bool
+		jwtAuthAvailable  bool
+		expectedURL       string
+		expectedCondition metav1.ConditionStatus
+		expectedReason    string
+	}
+	for _, tc := range []testCase{
+		{
+			name:             "no authorization required",
+			httpAPIs:         []*v1beta1.GatewayHTTPAPI{authAPI},
+			hasAuth:          true,
+			jwtAuthAvailable: true,
+		},
+		{
+			name:             "no auth module",
+			httpAPIs:         []*v1beta1.GatewayHTTPAPI{ledgerAPI},
+			jwtAuthAvailable: true,
+		},
+		{
+			name:              "jwtauth not available",
+			httpAPIs:          []*v1beta1.GatewayHTTPAPI{authAPI, ledgerAPI},
+			hasAuth:           true,
+			expectedCondition: metav1.ConditionFalse,
+			expectedReason:    "JWTAuthUnavailable",
+		},
+		{
+			name:              "auth api not created yet",
+			httpAPIs:          []*v1beta1.GatewayHTTPAPI{ledgerAPI},
+			hasAuth:           true,
+			jwtAuthAvailable:  true,
+			expectedCondition: metav1.ConditionFalse,
+			expectedReason:    "AuthAPINotFound",
+		},
+		{
+			name:              "enforced",
+			httpAPIs:          []*v1beta1.GatewayHTTPAPI{authAPI, ledgerAPI},
+			hasAuth:           true,
+			jwtAuthAvailable:  true,
+			expectedURL:       "http://auth:8080/keys",
+			expectedCondition: metav1.ConditionTrue,
+			expectedReason:    "Enforced",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &v1beta1.Gateway{}
+			require.Equal(t, tc.expectedURL, resolveJWKSURL(gateway, tc.httpAPIs, tc.hasAuth, tc.jwtAuthAvailable))
+
+			condition := gateway.GetConditions().Get(ConditionTypeAuthorizationEnforced)
+			if tc.expectedCondition == "" {
+				require.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			require.Equal(t, tc.expectedCondition, condition.Status)
+			require.Equal(t, tc.expectedReason, condition.Reason)
+		})
+	}
+}
@@ -3,11 +3,10 @@ package gateways
 import (
 	"strings"
 
-	collectionutils "github.com/formancehq/go-libs/v5/pkg/types/collections"
-
 	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	"github.com/formancehq/operator/v3/internal/core"
 	"github.com/formancehq/operator/v3/internal/resources/caddy"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
 )
 
 type CaddyOptions func(data map[string]any) error
@@ -15,13 +14,23 @@ type CaddyOptions func(data map[string]any) error
 func CreateCaddyfile(ctx core.Context, stack *v1beta1.Stack,
 	gateway *v1beta1.Gateway, httpAPIs []*v1beta1.GatewayHTTPAPI, broker *v1beta1.Broker, options ...CaddyOptions) (string, error) {
 
+	hasAuth, err := core.HasDependency(ctx, stack.Name, &v1beta1.Auth{})
+	if err != nil {
+		return "", err
+	}
+	jwtAuthAvailable, err := settings.GetBoolOrDefault(ctx, stack.Name, false, "gateway", "caddyfile", "jwtauth", "enabled")
+	if err != nil {
+		return "", err
+	}
+	jwksURL := resolveJWKSURL(gateway, httpAPIs, hasAuth, jwtAuthAvailable)
+
 	data := map[string]any{
-		"Services": collectionutils.Map(httpAPIs, func(from *v1beta1.GatewayHTTPAPI) v1beta1.GatewayHTTPAPISpec {
-			return from.Spec
-		}),
-		"Platform": ctx.GetPlatform(),
-		"Debug":    stack.Spec.Debug,
-		"Port":     8080,
+		"Services":            toCaddyServices(httpAPIs, hasAuth),
+		"EnableAuthorization": jwksURL != "",
+		"JWKSURL":             jwksURL,
+		"Platform":            ctx.GetPlatform(),
+		"Debug":               stack.Spec.Debug,
+		"Port":                8080,
 		"Gateway": map[string]any{
 			"Version": gateway.Spec.Version,
 		},
@@ -26,6 +26,8 @@ type handleJobConfiguration struct {
 	preCreate func() error
 	mutators  []core.ObjectMutator[*batchv1.Job]
 	validator func(job *batchv1.Job) bool
+	// watchedByOwner indicates the reconciler of the owner watches the job
+	watchedByOwner bool
 }
 
 type HandleJobOption func(configuration *handleJobConfiguration)
@@ -93,6 +95,14 @@ func WithPodFailurePolicy(p batchv1.PodFailurePolicy) HandleJobOption {
 	})
 }
 
+// WatchedByOwner indicates the reconciler of the owner watches its jobs (see core.WithOwn).
+// The owner is then only reconciled again when the job changes, instead of being retried periodically.
+func WatchedByOwner() HandleJobOption {
+	return func(configuration *handleJobConfiguration) {
+		configuration.watchedByOwner = true
+	}
+}
+
 func WithValidator(v func(job *batchv1.Job) bool) HandleJobOption {
 	return func(configuration *handleJobConfiguration) {
 		configuration.validator = v
@@ -180,6 +190,7 @@ func Handle(ctx core.Context, owner v1beta1.Dependent, jobName string, container
 		option(configuration)
 	}
 
+	pendingError := configuration.pendingError(jobName)
 	jobName = fmt.Sprintf("%s-%s", owner.GetUID(), jobName)
 	job := &batchv1.Job{}
 	err := ctx.GetClient().Get(ctx, types.NamespacedName{
@@ -204,7 +215,7 @@ func Handle(ctx core.Context, owner v1beta1.Dependent, jobName string, container
 				return err
 			}
 		} else {
-			return core.NewPendingError()
+			return pendingError
 		}
 	}
 
@@ -249,5 +260,13 @@ func Handle(ctx core.Context, owner v1beta1.Dependent, jobName string, container
 		return err
 	}
 
-	return core.NewPendingError()
+	return pendingError
+}
+
+func (c *handleJobConfiguration) pendingError(jobName string) error {
+	err := core.NewPendingError().WithMessage("waiting for job %s", jobName)
+	if c.watchedByOwner {
+		err = err.OnlyOnWatchEvent()
+	}
+	return err
 }
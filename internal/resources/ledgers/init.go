@@ -70,7 +70,7 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, ledger *v1beta1.Ledger, versio
 	}
 
 	if !database.Status.Ready {
-		return NewPendingError().WithMessage("database not ready")
+		return NewPendingError().WithMessage("database not ready").OnlyOnWatchEvent()
 	}
 
 	if databases.GetSavedModuleVersion(database) != version {
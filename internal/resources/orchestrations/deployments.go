@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	enumspb "go.temporal.io/api/enums/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +22,7 @@ import (
 	"github.com/formancehq/operator/v3/internal/resources/registries"
 	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
 	"github.com/formancehq/operator/v3/internal/resources/settings"
+	"github.com/formancehq/operator/v3/internal/resources/temporal"
 )
 
 func createAuthClient(ctx Context, stack *v1beta1.Stack, orchestration *v1beta1.Orchestration) (*v1beta1.AuthClient, error) {
@@ -86,6 +88,12 @@ func createDeployment(
 		return err
 	}
 
+	if err := temporal.EnsureNamespace(ctx, stack, temporalURI, map[string]enumspb.IndexedValueType{
+		"stack": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	}); err != nil {
+		return err
+	}
+
 	var temporalSecretResourceReference *v1beta1.ResourceReference
 	if secret := temporalURI.Query().Get("secret"); secret != "" {
 		temporalSecretResourceReference, err = resourcereferences.Create(ctx, orchestration, "temporal", secret, &corev1.Secret{})
@@ -120,27 +128,11 @@ func createDeployment(
 		env = append(env, authclients.GetEnvVars(client)...)
 	}
 
-	if secret := temporalURI.Query().Get("secret"); secret == "" {
-		temporalTLSCrt, err := settings.GetStringOrEmpty(ctx, stack.Name, "temporal", "tls", "crt")
-		if err != nil {
-			return err
-		}
-
-		temporalTLSKey, err := settings.GetStringOrEmpty(ctx, stack.Name, "temporal", "tls", "key")
-		if err != nil {
-			return err
-		}
-
-		env = append(env,
-			Env("TEMPORAL_SSL_CLIENT_KEY", temporalTLSKey),
-			Env("TEMPORAL_SSL_CLIENT_CERT", temporalTLSCrt),
-		)
-	} else {
-		env = append(env,
-			EnvFromSecret("TEMPORAL_SSL_CLIENT_KEY", secret, "tls.key"),
-			EnvFromSecret("TEMPORAL_SSL_CLIENT_CERT", secret, "tls.crt"),
-		)
+	tls, err := temporal.GetTLS(ctx, stack, orchestration, temporalURI)
+	if err != nil {
+		return err
 	}
+	env = append(env, tls.Env...)
 
 	if initSearchAttributes := temporalURI.Query().Get("initSearchAttributes"); initSearchAttributes == "true" {
 		env = append(env, Env("TEMPORAL_INIT_SEARCH_ATTRIBUTES", "true"))
@@ -165,10 +157,16 @@ func createDeployment(
 		return err
 	}
 
-	annotations := map[string]string{}
+	annotations, err := authclients.GetAnnotations(ctx, client)
+	if err != nil {
+		return err
+	}
 	if temporalSecretResourceReference != nil {
 		annotations["database-secret-hash"] = temporalSecretResourceReference.Status.Hash
 	}
+	if tls.Hash != "" {
+		annotations["temporal-tls-secret-hash"] = tls.Hash
+	}
 
 	maxParallelActivities, err := settings.GetIntOrDefault(ctx, stack.Name, 10, "orchestration", "max-parallel-activities")
 	if err != nil {
@@ -182,6 +180,9 @@ func createDeployment(
 		},
 		Spec: appsv1.DeploymentSpec{
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+				},
 				Spec: corev1.PodSpec{
 					ImagePullSecrets:   imageConfiguration.PullSecrets,
 					ServiceAccountName: serviceAccountName,
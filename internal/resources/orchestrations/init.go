@@ -34,6 +34,12 @@ import (
 //+kubebuilder:rbac:groups=formance.com,resources=orchestrations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=formance.com,resources=orchestrations/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=formance.com,resources=orchestrations/finalizers,verbs=update
+//+kubebuilder:rbac:groups=formance.com,resources=orchestrationworkflows,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=orchestrationworkflows/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=orchestrationworkflows/finalizers,verbs=update
+//+kubebuilder:rbac:groups=formance.com,resources=orchestrationtriggers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=orchestrationtriggers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=orchestrationtriggers/finalizers,verbs=update
 
 func Reconcile(ctx Context, stack *v1beta1.Stack, o *v1beta1.Orchestration, version string) error {
 
@@ -62,7 +68,7 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, o *v1beta1.Orchestration, vers
 	}
 
 	if !database.Status.Ready {
-		return NewPendingError().WithMessage("database not ready")
+		return NewPendingError().WithMessage("database not ready").OnlyOnWatchEvent()
 	}
 
 	if !topic.Status.Ready {
@@ -114,5 +120,16 @@ func init() {
 			databases.Watch[*v1beta1.Orchestration](),
 			brokers.Watch[*v1beta1.Orchestration](),
 		),
+		WithStackDependencyReconciler(ReconcileWorkflow,
+			WithFinalizer[*v1beta1.OrchestrationWorkflow]("delete-workflow", CleanWorkflow),
+			WithWatchDependency[*v1beta1.OrchestrationWorkflow](&v1beta1.Orchestration{}),
+			WithWatchDependency[*v1beta1.OrchestrationWorkflow](&v1beta1.AuthClient{}),
+		),
+		WithStackDependencyReconciler(ReconcileTrigger,
+			WithFinalizer[*v1beta1.OrchestrationTrigger]("delete-trigger", CleanTrigger),
+			WithWatchDependency[*v1beta1.OrchestrationTrigger](&v1beta1.Orchestration{}),
+			WithWatchDependency[*v1beta1.OrchestrationTrigger](&v1beta1.AuthClient{}),
+			WithWatchDependency[*v1beta1.OrchestrationTrigger](&v1beta1.OrchestrationWorkflow{}),
+		),
 	)
 }
@@ -0,0 +1,131 @@
+package orchestrations
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+)
+
+type trigger struct {
+	ID string `json:"id"`
+}
+
+func ReconcileTrigger(ctx core.Context, stack *v1beta1.Stack, t *v1beta1.OrchestrationTrigger) error {
+	w := &v1beta1.OrchestrationWorkflow{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: t.Spec.Workflow,
+	}, w); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return core.NewPendingError().WithMessage("workflow %s not found", t.Spec.Workflow)
+		}
+		return err
+	}
+	if w.Spec.Stack != t.Spec.Stack {
+		return core.NewApplicationError().WithMessage("workflow %s is not part of stack %s", t.Spec.Workflow, t.Spec.Stack)
+	}
+	if !w.Status.Ready || w.Status.WorkflowID == "" {
+		return core.NewPendingError().WithMessage("waiting for workflow %s to be ready", t.Spec.Workflow)
+	}
+
+	config := triggerConfig(t, w.Status.WorkflowID)
+	configHash, err := hashConfig(config)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newAPIClient(ctx, stack, t)
+	if err != nil {
+		return err
+	}
+
+	return reconcileTrigger(ctx, apiClient, t, config, configHash)
+}
+
+func reconcileTrigger(ctx core.Context, apiClient *apiclients.Client, t *v1beta1.OrchestrationTrigger,
+	config map[string]any, configHash string) error {
+
+	if err := deletePending(ctx, apiClient, &t.Status.PendingDeletion, triggerPath); err != nil {
+		return err
+	}
+
+	if t.Status.TriggerID != "" && t.Status.ConfigHash == configHash {
+		err := apiClient.Do(ctx, http.MethodGet, triggerPath(t.Status.TriggerID), nil, nil)
+		switch {
+		case err == nil:
+			return nil
+		case !apiclients.IsNotFound(err):
+			return core.NewApplicationError().WithMessage("getting trigger: %s", err)
+		}
+		// The trigger has been removed externally, create it again
+	}
+
+	log.FromContext(ctx).Info("Creating orchestration trigger", "name", t.GetTriggerName())
+	ret := apiclients.Data[trigger]{}
+	if err := apiClient.Do(ctx, http.MethodPost, "/v2/triggers", config, &ret); err != nil {
+		return core.NewApplicationError().WithMessage("creating trigger: %s", err)
+	}
+
+	// The previous trigger is kept in the status until its deletion succeeds
+	if t.Status.TriggerID != "" && t.Status.TriggerID != ret.Data.ID {
+		t.Status.PendingDeletion = append(t.Status.PendingDeletion, t.Status.TriggerID)
+	}
+	t.Status.TriggerID = ret.Data.ID
+	t.Status.WorkflowID = config["workflowID"].(string)
+	t.Status.ConfigHash = configHash
+
+	return deletePending(ctx, apiClient, &t.Status.PendingDeletion, triggerPath)
+}
+
+func triggerConfig(t *v1beta1.OrchestrationTrigger, workflowID string) map[string]any {
+	config := map[string]any{
+		"name":       t.GetTriggerName(),
+		"event":      t.Spec.Event,
+		"workflowID": workflowID,
+	}
+	if t.Spec.Filter != "" {
+		config["filter"] = t.Spec.Filter
+	}
+	if len(t.Spec.Vars) > 0 {
+		config["vars"] = t.Spec.Vars
+	}
+
+	return config
+}
+
+func triggerPath(id string) string {
+	return fmt.Sprintf("/v2/triggers/%s", url.PathEscape(id))
+}
+
+func CleanTrigger(ctx core.Context, t *v1beta1.OrchestrationTrigger) error {
+	if t.Status.TriggerID == "" && len(t.Status.PendingDeletion) == 0 {
+		return nil
+	}
+
+	apiClient, err := newCleanupAPIClient(ctx, t)
+	if err != nil || apiClient == nil {
+		return err
+	}
+
+	if err := deletePending(ctx, apiClient, &t.Status.PendingDeletion, triggerPath); err != nil {
+		return err
+	}
+	if t.Status.TriggerID == "" {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Deleting orchestration trigger", "id", t.Status.TriggerID)
+	if err := apiClient.Do(ctx, http.MethodDelete, triggerPath(t.Status.TriggerID), nil, nil); err != nil &&
+		!apiclients.IsNotFound(err) {
+		return core.NewApplicationError().WithMessage("deleting trigger: %s", err)
+	}
+
+	return nil
+}
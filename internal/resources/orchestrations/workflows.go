@@ -0,0 +1,223 @@
+package orchestrations
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+)
+
+type workflow struct {
+	ID     string         `json:"id"`
+	Config map[string]any `json:"config"`
+}
+
+func ReconcileWorkflow(ctx core.Context, stack *v1beta1.Stack, w *v1beta1.OrchestrationWorkflow) error {
+	config, err := workflowConfig(w)
+	if err != nil {
+		return err
+	}
+	configHash, err := hashConfig(config)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newAPIClient(ctx, stack, w)
+	if err != nil {
+		return err
+	}
+
+	return reconcileWorkflow(ctx, apiClient, w, config, configHash)
+}
+
+func reconcileWorkflow(ctx core.Context, apiClient *apiclients.Client, w *v1beta1.OrchestrationWorkflow,
+	config map[string]any, configHash string) error {
+
+	if err := deletePending(ctx, apiClient, &w.Status.PendingDeletion, workflowPath); err != nil {
+		return err
+	}
+
+	if w.Status.WorkflowID == "" {
+		existing, err := findWorkflow(ctx, apiClient, w.GetWorkflowName(), configHash)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			w.Status.WorkflowID = existing.ID
+			w.Status.DefinitionHash = configHash
+			if w.Status.Version == 0 {
+				w.Status.Version = 1
+			}
+		}
+	} else if w.Status.DefinitionHash == configHash {
+		err := apiClient.Do(ctx, http.MethodGet, workflowPath(w.Status.WorkflowID), nil, nil)
+		switch {
+		case apiclients.IsNotFound(err):
+			// The workflow has been removed externally, create it again
+			w.Status.DefinitionHash = ""
+		case err != nil:
+			return core.NewApplicationError().WithMessage("getting workflow: %s", err)
+		}
+	}
+
+	if w.Status.WorkflowID != "" && w.Status.DefinitionHash == configHash {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Creating orchestration workflow", "name", w.GetWorkflowName(), "version", w.Status.Version+1)
+	ret := apiclients.Data[workflow]{}
+	if err := apiClient.Do(ctx, http.MethodPost, "/v2/workflows", config, &ret); err != nil {
+		return core.NewApplicationError().WithMessage("creating workflow: %s", err)
+	}
+
+	// The previous version is kept in the status until its deletion succeeds
+	if w.Status.WorkflowID != "" && w.Status.WorkflowID != ret.Data.ID {
+		w.Status.PendingDeletion = append(w.Status.PendingDeletion, w.Status.WorkflowID)
+	}
+	w.Status.WorkflowID = ret.Data.ID
+	w.Status.DefinitionHash = configHash
+	w.Status.Version++
+
+	return deletePending(ctx, apiClient, &w.Status.PendingDeletion, workflowPath)
+}
+
+// deletePending deletes the replaced objects listed in ids, they are removed from the list once deleted
+func deletePending(ctx core.Context, apiClient *apiclients.Client, ids *[]string, path func(string) string) error {
+	for len(*ids) > 0 {
+		id := (*ids)[0]
+		log.FromContext(ctx).Info("Deleting previous orchestration object", "path", path(id))
+		if err := apiClient.Do(ctx, http.MethodDelete, path(id), nil, nil); err != nil &&
+			!apiclients.IsNotFound(err) {
+			return core.NewApplicationError().WithMessage("deleting %s: %s", path(id), err)
+		}
+		*ids = (*ids)[1:]
+	}
+	*ids = nil
+
+	return nil
+}
+
+// findWorkflow allow to retrieve a workflow previously created, in case the status of the object has been lost.
+// Only a workflow with the same definition is returned, otherwise a new version will be created.
+func findWorkflow(ctx core.Context, apiClient *apiclients.Client, name, configHash string) (*workflow, error) {
+	query := url.Values{}
+	query.Set("pageSize", "100")
+	for {
+		ret := apiclients.Cursor[workflow]{}
+		if err := apiClient.Do(ctx, http.MethodGet, "/v2/workflows?"+query.Encode(), nil, &ret); err != nil {
+			return nil, core.NewApplicationError().WithMessage("listing workflows: %s", err)
+		}
+		for _, w := range ret.Cursor.Data {
+			if w.Config["name"] != name {
+				continue
+			}
+			hash, err := hashConfig(w.Config)
+			if err != nil {
+				return nil, err
+			}
+			if hash == configHash {
+				return &w, nil
+			}
+		}
+		if !ret.Cursor.HasMore {
+			return nil, nil
+		}
+		query = url.Values{}
+		query.Set("cursor", ret.Cursor.Next)
+	}
+}
+
+func workflowConfig(w *v1beta1.OrchestrationWorkflow) (map[string]any, error) {
+	config := map[string]any{}
+	if err := yaml.Unmarshal([]byte(w.Spec.Definition), &config); err != nil {
+		return nil, core.NewApplicationError().WithMessage("invalid workflow definition: %s", err)
+	}
+	if _, ok := config["stages"]; !ok {
+		return nil, core.NewApplicationError().WithMessage("invalid workflow definition: missing stages")
+	}
+	config["name"] = w.GetWorkflowName()
+
+	return config, nil
+}
+
+func workflowPath(id string) string {
+	return fmt.Sprintf("/v2/workflows/%s", url.PathEscape(id))
+}
+
+func CleanWorkflow(ctx core.Context, w *v1beta1.OrchestrationWorkflow) error {
+	if w.Status.WorkflowID == "" && len(w.Status.PendingDeletion) == 0 {
+		return nil
+	}
+
+	apiClient, err := newCleanupAPIClient(ctx, w)
+	if err != nil || apiClient == nil {
+		return err
+	}
+
+	if err := deletePending(ctx, apiClient, &w.Status.PendingDeletion, workflowPath); err != nil {
+		return err
+	}
+	if w.Status.WorkflowID == "" {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Deleting orchestration workflow", "id", w.Status.WorkflowID)
+	if err := apiClient.Do(ctx, http.MethodDelete, workflowPath(w.Status.WorkflowID), nil, nil); err != nil &&
+		!apiclients.IsNotFound(err) {
+		return core.NewApplicationError().WithMessage("deleting workflow: %s", err)
+	}
+
+	return nil
+}
+
+// newAPIClient creates a client for the orchestration v2 API of the stack of the object.
+// It also ensures the object is owned by the stack, so it is removed with it.
+func newAPIClient(ctx core.Context, stack *v1beta1.Stack, object v1beta1.Dependent) (*apiclients.Client, error) {
+	orchestration := &v1beta1.Orchestration{}
+	if err := core.GetModuleOfDependent(ctx, stack, object, orchestration); err != nil {
+		return nil, err
+	}
+
+	version, err := core.ResolveModuleVersion(ctx, stack, orchestration)
+	if err != nil {
+		return nil, err
+	}
+	if semver.IsValid(version) && semver.Compare(version, "v2.0.0-beta.1") < 0 {
+		return nil, core.NewApplicationError().WithMessage("orchestration workflows require orchestration >= v2.0.0, actual: %s", version)
+	}
+
+	return apiclients.New(ctx, stack, orchestration, "orchestration:read", "orchestration:write")
+}
+
+// newCleanupAPIClient returns a client for the orchestration API, or nil if the orchestration module is being removed,
+// in which case the objects will be removed with it
+func newCleanupAPIClient(ctx core.Context, object v1beta1.Dependent) (*apiclients.Client, error) {
+	orchestration := &v1beta1.Orchestration{}
+	stack, available, err := core.GetModuleForCleanup(ctx, object.GetStack(), orchestration)
+	if err != nil || !available {
+		return nil, err
+	}
+
+	return apiclients.New(ctx, stack, orchestration, "orchestration:read", "orchestration:write")
+}
+
+func hashConfig(config map[string]any) (string, error) {
+	// json.Marshal sorts map keys, so the hash does not depend on the order of the keys
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(data)
+
+	return base64.StdEncoding.EncodeToString(digest[:]), nil
+}
@@ -0,0 +1,269 @@
+package orchestrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core/coretest"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients/apiclientstest"
+)
+
+type fakeOrchestrationAPI struct {
+	nextID    int
+	workflows map[string]map[string]any
+	triggers  map[string]map[string]any
+	// failDeletes makes the deletions fail
+	failDeletes bool
+}
+
+func (f *fakeOrchestrationAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	create := func(objects map[string]map[string]any, prefix string) {
+		f.nextID++
+		id := fmt.Sprintf("%s-%d", prefix, f.nextID)
+		object := map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&object)
+		objects[id] = object
+		apiclientstest.WriteJSON(w, http.StatusCreated, map[string]any{"data": map[string]any{"id": id, "config": object}})
+	}
+	handleObject := func(objects map[string]map[string]any, id string) {
+		if _, ok := objects[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"id": id}})
+		case http.MethodDelete:
+			if f.failDeletes {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			delete(objects, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/workflows":
+		data := make([]any, 0)
+		for id, config := range f.workflows {
+			data = append(data, map[string]any{"id": id, "config": config})
+		}
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": data}})
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/workflows":
+		create(f.workflows, "workflow")
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/triggers":
+		create(f.triggers, "trigger")
+	case strings.HasPrefix(r.URL.Path, "/v2/workflows/"):
+		handleObject(f.workflows, strings.TrimPrefix(r.URL.Path, "/v2/workflows/"))
+	case strings.HasPrefix(r.URL.Path, "/v2/triggers/"):
+		handleObject(f.triggers, strings.TrimPrefix(r.URL.Path, "/v2/triggers/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+type orchestrationTest struct {
+	t         *testing.T
+	api       *fakeOrchestrationAPI
+	apiClient *apiclients.Client
+	workflow  *v1beta1.OrchestrationWorkflow
+	trigger   *v1beta1.OrchestrationTrigger
+}
+
+func (o orchestrationTest) tryReconcileWorkflow() error {
+	o.t.Helper()
+	config, err := workflowConfig(o.workflow)
+	require.NoError(o.t, err)
+	hash, err := hashConfig(config)
+	require.NoError(o.t, err)
+	return reconcileWorkflow(coretest.NewContext(), o.apiClient, o.workflow, config, hash)
+}
+
+func (o orchestrationTest) reconcileWorkflow() {
+	o.t.Helper()
+	require.NoError(o.t, o.tryReconcileWorkflow())
+}
+
+func (o orchestrationTest) tryReconcileTrigger() error {
+	o.t.Helper()
+	config := triggerConfig(o.trigger, o.workflow.Status.WorkflowID)
+	hash, err := hashConfig(config)
+	require.NoError(o.t, err)
+	return reconcileTrigger(coretest.NewContext(), o.apiClient, o.trigger, config, hash)
+}
+
+func (o orchestrationTest) reconcileTrigger() {
+	o.t.Helper()
+	require.NoError(o.t, o.tryReconcileTrigger())
+}
+
+// newOrchestrationTest returns a test whose workflow and trigger have already been created on the fake API
+func newOrchestrationTest(t *testing.T) orchestrationTest {
+	t.Helper()
+
+	api := &fakeOrchestrationAPI{
+		workflows: map[string]map[string]any{},
+		triggers:  map[string]map[string]any{},
+	}
+	ret := orchestrationTest{
+		t:         t,
+		api:       api,
+		apiClient: apiclientstest.NewClient(t, api),
+		workflow: &v1beta1.OrchestrationWorkflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "payout",
+			},
+			Spec: v1beta1.OrchestrationWorkflowSpec{
+				Definition: "stages:\n- delay:\n    duration: 1m\n",
+			},
+		},
+		trigger: &v1beta1.OrchestrationTrigger{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "on-payment",
+			},
+			Spec: v1beta1.OrchestrationTriggerSpec{
+				Event:    "SAVED_PAYMENT",
+				Workflow: "payout",
+			},
+		},
+	}
+	ret.reconcileWorkflow()
+	ret.reconcileTrigger()
+
+	return ret
+}
+
+func TestReconcileWorkflowAndTriggerCreate(t *testing.T) {
+	t.Parallel()
+
+	test := newOrchestrationTest(t)
+
+	require.Equal(t, 1, test.workflow.Status.Version)
+	require.Contains(t, test.api.workflows, test.workflow.Status.WorkflowID)
+	require.Equal(t, "payout", test.api.workflows[test.workflow.Status.WorkflowID]["name"])
+	require.Contains(t, test.api.triggers, test.trigger.Status.TriggerID)
+	require.Equal(t, test.workflow.Status.WorkflowID, test.api.triggers[test.trigger.Status.TriggerID]["workflowID"])
+}
+
+func TestReconcileWorkflowAndTriggerUnchanged(t *testing.T) {
+	t.Parallel()
+
+	test := newOrchestrationTest(t)
+	workflowID, triggerID := test.workflow.Status.WorkflowID, test.trigger.Status.TriggerID
+
+	test.reconcileWorkflow()
+	test.reconcileTrigger()
+	require.Equal(t, workflowID, test.workflow.Status.WorkflowID)
+	require.Equal(t, triggerID, test.trigger.Status.TriggerID)
+	require.Len(t, test.api.workflows, 1)
+	require.Len(t, test.api.triggers, 1)
+}
+
+func TestReconcileWorkflowStatusLost(t *testing.T) {
+	t.Parallel()
+
+	test := newOrchestrationTest(t)
+	workflowID := test.workflow.Status.WorkflowID
+
+	// A new workflow is not created
+	test.workflow.Status = v1beta1.OrchestrationWorkflowStatus{}
+	test.reconcileWorkflow()
+	require.Equal(t, workflowID, test.workflow.Status.WorkflowID)
+	require.Len(t, test.api.workflows, 1)
+}
+
+func TestReconcileWorkflowDefinition(t *testing.T) {
+	t.Parallel()
+
+	test := newOrchestrationTest(t)
+	workflowID, triggerID := test.workflow.Status.WorkflowID, test.trigger.Status.TriggerID
+
+	// A new version is created and the trigger is replaced
+	test.workflow.Spec.Definition = "stages:\n- delay:\n    duration: 2m\n"
+	test.reconcileWorkflow()
+	require.NotEqual(t, workflowID, test.workflow.Status.WorkflowID)
+	require.Equal(t, 2, test.workflow.Status.Version)
+	require.Len(t, test.api.workflows, 1)
+
+	test.reconcileTrigger()
+	require.NotEqual(t, triggerID, test.trigger.Status.TriggerID)
+	require.Equal(t, test.workflow.Status.WorkflowID, test.trigger.Status.WorkflowID)
+	require.Len(t, test.api.triggers, 1)
+}
+
+func TestReconcileTriggerRemovedExternally(t *testing.T) {
+	t.Parallel()
+
+	test := newOrchestrationTest(t)
+
+	// The trigger is created again
+	delete(test.api.triggers, test.trigger.Status.TriggerID)
+	test.reconcileTrigger()
+	require.Contains(t, test.api.triggers, test.trigger.Status.TriggerID)
+}
+
+func TestReconcileWorkflowAndTriggerDeletionRetried(t *testing.T) {
+	t.Parallel()
+
+	test := newOrchestrationTest(t)
+	workflowID, triggerID := test.workflow.Status.WorkflowID, test.trigger.Status.TriggerID
+
+	test.api.failDeletes = true
+	test.workflow.Spec.Definition = "stages:\n- delay:\n    duration: 2m\n"
+	require.Error(t, test.tryReconcileWorkflow())
+	require.Equal(t, []string{workflowID}, test.workflow.Status.PendingDeletion)
+	require.Len(t, test.api.workflows, 2)
+
+	require.Error(t, test.tryReconcileTrigger())
+	require.Equal(t, []string{triggerID}, test.trigger.Status.PendingDeletion)
+	require.Len(t, test.api.triggers, 2)
+
+	test.api.failDeletes = false
+	test.reconcileWorkflow()
+	test.reconcileTrigger()
+	require.Empty(t, test.workflow.Status.PendingDeletion)
+	require.Empty(t, test.trigger.Status.PendingDeletion)
+	require.Len(t, test.api.workflows, 1)
+	require.Len(t, test.api.triggers, 1)
+	require.Contains(t, test.api.workflows, test.workflow.Status.WorkflowID)
+	require.Contains(t, test.api.triggers, test.trigger.Status.TriggerID)
+}
+
+func TestWorkflowConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := workflowConfig(&v1beta1.OrchestrationWorkflow{
+		Spec: v1beta1.OrchestrationWorkflowSpec{
+			Definition: "name: test\n",
+		},
+	})
+	require.Error(t, err)
+
+	_, err = workflowConfig(&v1beta1.OrchestrationWorkflow{
+		Spec: v1beta1.OrchestrationWorkflowSpec{
+			Definition: "- invalid",
+		},
+	})
+	require.Error(t, err)
+
+	config, err := workflowConfig(&v1beta1.OrchestrationWorkflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "resource-name",
+		},
+		Spec: v1beta1.OrchestrationWorkflowSpec{
+			Name:       "custom-name",
+			Definition: "name: ignored\nstages: []\n",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "custom-name", config["name"])
+}
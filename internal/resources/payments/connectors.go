@@ -0,0 +1,313 @@
+package payments
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"golang.org/x/mod/semver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
+)
+
+const (
+	ConditionTypeConnectorHealthy = "ConnectorHealthy"
+
+	connectorsRefreshInterval = time.Minute
+)
+
+type connector struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Config struct {
+		Name string `json:"name"`
+	} `json:"config"`
+	Provider             string `json:"provider"`
+	ScheduledForDeletion bool   `json:"scheduledForDeletion"`
+}
+
+type connectorSchedule struct {
+	ID string `json:"id"`
+}
+
+type connectorScheduleInstance struct {
+	CreatedAt    time.Time  `json:"createdAt"`
+	Terminated   bool       `json:"terminated"`
+	TerminatedAt *time.Time `json:"terminatedAt,omitempty"`
+	Error        *string    `json:"error,omitempty"`
+}
+
+func ReconcileConnector(ctx core.Context, stack *v1beta1.Stack, pc *v1beta1.PaymentsConnector) error {
+	payments := &v1beta1.Payments{}
+	if err := core.GetModuleOfDependent(ctx, stack, pc, payments); err != nil {
+		return err
+	}
+
+	version, err := core.ResolveModuleVersion(ctx, stack, payments)
+	if err != nil {
+		return err
+	}
+	if semver.IsValid(version) && semver.Compare(version, "v3.0.0-beta.1") < 0 {
+		return core.NewApplicationError().WithMessage("payments connectors require payments >= v3.0.0, actual: %s", version)
+	}
+
+	config, err := connectorConfig(ctx, pc)
+	if err != nil {
+		return err
+	}
+	configHash, err := hashConnectorConfig(config)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := apiclients.New(ctx, stack, payments, "payments:read", "payments:write")
+	if err != nil {
+		return err
+	}
+
+	return reconcileConnector(ctx, apiClient, pc, config, configHash)
+}
+
+func reconcileConnector(ctx core.Context, apiClient *apiclients.Client, pc *v1beta1.PaymentsConnector,
+	config map[string]any, configHash string) error {
+
+	if pc.Status.ConnectorID == "" {
+		existing, err := findConnector(ctx, apiClient, pc)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			pc.Status.ConnectorID = existing.ID
+		}
+	}
+
+	switch {
+	case pc.Status.ConnectorID == "":
+		log.FromContext(ctx).Info("Installing payments connector", "provider", pc.Spec.Provider)
+		ret := apiclients.Data[string]{}
+		if err := apiClient.Do(ctx, http.MethodPost,
+			fmt.Sprintf("/v3/connectors/install/%s", url.PathEscape(pc.Spec.Provider)), config, &ret); err != nil {
+			return core.NewApplicationError().WithMessage("installing connector: %s", err)
+		}
+		pc.Status.ConnectorID = ret.Data
+		pc.Status.ConfigHash = configHash
+	case pc.Status.ConfigHash != configHash:
+		log.FromContext(ctx).Info("Updating payments connector config", "id", pc.Status.ConnectorID)
+		if err := apiClient.Do(ctx, http.MethodPatch,
+			fmt.Sprintf("/v3/connectors/%s/config", url.PathEscape(pc.Status.ConnectorID)), config, nil); err != nil {
+			if apiclients.IsNotFound(err) {
+				// The connector has been removed externally, it will be installed again on next reconciliation
+				pc.Status.ConnectorID = ""
+				pc.Status.ConfigHash = ""
+			}
+			return core.NewApplicationError().WithMessage("updating connector config: %s", err)
+		}
+		pc.Status.ConfigHash = configHash
+	}
+
+	return updateConnectorHealth(ctx, apiClient, pc)
+}
+
+// findConnector allow to retrieve a connector previously installed, in case the status of the object has been lost
+func findConnector(ctx core.Context, apiClient *apiclients.Client, pc *v1beta1.PaymentsConnector) (*connector, error) {
+	query := url.Values{}
+	query.Set("pageSize", "100")
+	for {
+		ret := apiclients.Cursor[connector]{}
+		if err := apiClient.Do(ctx, http.MethodGet, "/v3/connectors?"+query.Encode(), nil, &ret); err != nil {
+			return nil, core.NewApplicationError().WithMessage("listing connectors: %s", err)
+		}
+		for _, c := range ret.Cursor.Data {
+			if c.ScheduledForDeletion || c.Provider != pc.Spec.Provider {
+				continue
+			}
+			name := c.Name
+			if name == "" {
+				name = c.Config.Name
+			}
+			if name == pc.GetConnectorName() {
+				return &c, nil
+			}
+		}
+		if !ret.Cursor.HasMore {
+			return nil, nil
+		}
+		query = url.Values{}
+		query.Set("cursor", ret.Cursor.Next)
+	}
+}
+
+func updateConnectorHealth(ctx core.Context, apiClient *apiclients.Client, pc *v1beta1.PaymentsConnector) error {
+	schedules := apiclients.Cursor[connectorSchedule]{}
+	if err := apiClient.Do(ctx, http.MethodGet,
+		fmt.Sprintf("/v3/connectors/%s/schedules?pageSize=100", url.PathEscape(pc.Status.ConnectorID)), nil, &schedules); err != nil {
+		return core.NewApplicationError().WithMessage("listing connector schedules: %s", err)
+	}
+
+	var (
+		lastPollTime *time.Time
+		lastError    string
+	)
+	for _, schedule := range schedules.Cursor.Data {
+		instances := apiclients.Cursor[connectorScheduleInstance]{}
+		if err := apiClient.Do(ctx, http.MethodGet,
+			fmt.Sprintf("/v3/connectors/%s/schedules/%s/instances?pageSize=1",
+				url.PathEscape(pc.Status.ConnectorID), url.PathEscape(schedule.ID)), nil, &instances); err != nil {
+			return core.NewApplicationError().WithMessage("listing connector schedule instances: %s", err)
+		}
+		if len(instances.Cursor.Data) == 0 {
+			continue
+		}
+
+		instance := instances.Cursor.Data[0]
+		pollTime := instance.CreatedAt
+		if instance.TerminatedAt != nil {
+			pollTime = *instance.TerminatedAt
+		}
+		if lastPollTime == nil || pollTime.After(*lastPollTime) {
+			lastPollTime = &pollTime
+		}
+		if instance.Error != nil && *instance.Error != "" {
+			lastError = *instance.Error
+		}
+	}
+
+	if lastPollTime != nil {
+		pc.Status.LastPollTime = &metav1.Time{Time: *lastPollTime}
+	}
+
+	condition := v1beta1.NewCondition(ConditionTypeConnectorHealthy, pc.Generation).
+		SetMessage("Connector is polling the provider successfully")
+	if lastError != "" {
+		condition.Fail(lastError).SetReason("PollingFailed")
+	}
+	pc.GetConditions().AppendOrReplace(*condition, v1beta1.ConditionTypeMatch(ConditionTypeConnectorHealthy))
+
+	return nil
+}
+
+func connectorConfig(ctx core.Context, pc *v1beta1.PaymentsConnector) (map[string]any, error) {
+	config := map[string]any{}
+	for key, value := range pc.Spec.Config {
+		var v any
+		if err := json.Unmarshal(value.Raw, &v); err != nil {
+			return nil, fmt.Errorf("decoding config key '%s': %w", key, err)
+		}
+		config[key] = v
+	}
+	config["name"] = pc.GetConnectorName()
+
+	secrets := map[string]*corev1.Secret{}
+	for key, selector := range pc.Spec.Credentials {
+		secret, ok := secrets[selector.Name]
+		if !ok {
+			var err error
+			secret, err = resourcereferences.GetSecret(ctx, pc, credentialsReferenceName(selector.Name), selector.Name)
+			if err != nil {
+				return nil, err
+			}
+			secrets[selector.Name] = secret
+		}
+
+		value, ok := secret.Data[selector.Key]
+		if !ok {
+			return nil, core.NewApplicationError().WithMessage("key '%s' not found in secret '%s'", selector.Key, selector.Name)
+		}
+		config[key] = string(value)
+	}
+
+	if err := cleanCredentialsReferences(ctx, pc, secrets); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// cleanCredentialsReferences removes references to secrets no longer used by the connector
+func cleanCredentialsReferences(ctx core.Context, pc *v1beta1.PaymentsConnector, secrets map[string]*corev1.Secret) error {
+	list := &v1beta1.ResourceReferenceList{}
+	if err := ctx.GetClient().List(ctx, list, client.MatchingFields{
+		"stack": pc.Spec.Stack,
+	}); err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		hasControllerReference, err := core.HasControllerReference(ctx, pc, &item)
+		if err != nil {
+			return err
+		}
+		if !hasControllerReference {
+			continue
+		}
+		if _, ok := secrets[item.Spec.Name]; ok {
+			continue
+		}
+		if err := resourcereferences.Delete(ctx, pc, credentialsReferenceName(item.Spec.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func credentialsReferenceName(secret string) string {
+	return fmt.Sprintf("credentials-%s", secret)
+}
+
+func hashConnectorConfig(config map[string]any) (string, error) {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	digest := sha256.New()
+	for _, key := range keys {
+		data, err := json.Marshal(config[key])
+		if err != nil {
+			return "", err
+		}
+		_, _ = digest.Write([]byte(key))
+		_, _ = digest.Write(data)
+	}
+
+	return base64.StdEncoding.EncodeToString(digest.Sum(nil)), nil
+}
+
+func CleanConnector(ctx core.Context, pc *v1beta1.PaymentsConnector) error {
+	if pc.Status.ConnectorID == "" {
+		return nil
+	}
+
+	payments := &v1beta1.Payments{}
+	stack, available, err := core.GetModuleForCleanup(ctx, pc.Spec.Stack, payments)
+	if err != nil || !available {
+		return err
+	}
+
+	apiClient, err := apiclients.New(ctx, stack, payments, "payments:read", "payments:write")
+	if err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Uninstalling payments connector", "id", pc.Status.ConnectorID)
+	if err := apiClient.Do(ctx, http.MethodDelete,
+		fmt.Sprintf("/v3/connectors/%s", url.PathEscape(pc.Status.ConnectorID)), nil, nil); err != nil && !apiclients.IsNotFound(err) {
+		return core.NewApplicationError().WithMessage("uninstalling connector: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package payments
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core/coretest"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients/apiclientstest"
+)
+
+type fakePaymentsAPI struct {
+	installed  map[string]any
+	updated    map[string]any
+	pollError  string
+	lastPollAt time.Time
+}
+
+func (f *fakePaymentsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/connectors":
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": []any{}}})
+	case r.Method == http.MethodPost && r.URL.Path == "/v3/connectors/install/stripe":
+		f.installed = map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&f.installed)
+		apiclientstest.WriteJSON(w, http.StatusAccepted, map[string]any{"data": "connector-1"})
+	case r.Method == http.MethodPatch && r.URL.Path == "/v3/connectors/connector-1/config":
+		f.updated = map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&f.updated)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/connectors/connector-1/schedules":
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": []any{
+			map[string]any{"id": "schedule-1"},
+		}}})
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/connectors/connector-1/schedules/schedule-1/instances":
+		instance := map[string]any{
+			"createdAt":    f.lastPollAt,
+			"terminated":   true,
+			"terminatedAt": f.lastPollAt,
+		}
+		if f.pollError != "" {
+			instance["error"] = f.pollError
+		}
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": []any{instance}}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// newConnectorTest returns the fake API, a client targeting it, and a connector already installed on it
+func newConnectorTest(t *testing.T) (*fakePaymentsAPI, *apiclients.Client, *v1beta1.PaymentsConnector, string) {
+	t.Helper()
+
+	api := &fakePaymentsAPI{
+		lastPollAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	apiClient := apiclientstest.NewClient(t, api)
+
+	pc := &v1beta1.PaymentsConnector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "stripe",
+			Generation: 1,
+		},
+		Spec: v1beta1.PaymentsConnectorSpec{
+			Provider: "stripe",
+		},
+	}
+
+	config := map[string]any{"name": "stripe", "apiKey": "key1"}
+	hash, err := hashConnectorConfig(config)
+	require.NoError(t, err)
+	require.NoError(t, reconcileConnector(coretest.NewContext(), apiClient, pc, config, hash))
+
+	return api, apiClient, pc, hash
+}
+
+func TestReconcileConnectorInstall(t *testing.T) {
+	t.Parallel()
+
+	api, _, pc, hash := newConnectorTest(t)
+
+	require.Equal(t, "connector-1", pc.Status.ConnectorID)
+	require.Equal(t, hash, pc.Status.ConfigHash)
+	require.Equal(t, "key1", api.installed["apiKey"])
+	require.NotNil(t, pc.Status.LastPollTime)
+	require.True(t, pc.Status.LastPollTime.Equal(&metav1.Time{Time: api.lastPollAt}))
+	require.True(t, pc.GetConditions().Check(v1beta1.ConditionTypeMatch(ConditionTypeConnectorHealthy)))
+}
+
+func TestReconcileConnectorUnchanged(t *testing.T) {
+	t.Parallel()
+
+	api, apiClient, pc, hash := newConnectorTest(t)
+
+	config := map[string]any{"name": "stripe", "apiKey": "key1"}
+	require.NoError(t, reconcileConnector(coretest.NewContext(), apiClient, pc, config, hash))
+	require.Nil(t, api.updated)
+}
+
+func TestReconcileConnectorUpdate(t *testing.T) {
+	t.Parallel()
+
+	api, apiClient, pc, hash := newConnectorTest(t)
+
+	config := map[string]any{"name": "stripe", "apiKey": "key2"}
+	newHash, err := hashConnectorConfig(config)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, newHash)
+	require.NoError(t, reconcileConnector(coretest.NewContext(), apiClient, pc, config, newHash))
+	require.Equal(t, "key2", api.updated["apiKey"])
+	require.Equal(t, newHash, pc.Status.ConfigHash)
+}
+
+func TestReconcileConnectorPollError(t *testing.T) {
+	t.Parallel()
+
+	api, apiClient, pc, hash := newConnectorTest(t)
+
+	api.pollError = "invalid api key"
+	config := map[string]any{"name": "stripe", "apiKey": "key1"}
+	require.NoError(t, reconcileConnector(coretest.NewContext(), apiClient, pc, config, hash))
+
+	condition := pc.GetConditions().Get(ConditionTypeConnectorHealthy)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, "invalid api key", condition.Message)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	enumspb "go.temporal.io/api/enums/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,6 +24,7 @@ import (
 	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
 	"github.com/formancehq/operator/v3/internal/resources/services"
 	"github.com/formancehq/operator/v3/internal/resources/settings"
+	"github.com/formancehq/operator/v3/internal/resources/temporal"
 )
 
 func getEncryptionKey(ctx core.Context, payments *v1beta1.Payments) (string, error) {
@@ -49,6 +51,12 @@ func temporalEnvVars(ctx core.Context, stack *v1beta1.Stack, payments *v1beta1.P
 		return
 	}
 
+	if err = temporal.EnsureNamespace(ctx, stack, temporalURI, map[string]enumspb.IndexedValueType{
+		"Stack": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	}); err != nil {
+		return
+	}
+
 	if secret := temporalURI.Query().Get("secret"); secret != "" {
 		ref, err = resourcereferences.Create(ctx, payments, "payments-temporal", secret, &corev1.Secret{})
 		hash[ref.Name] = ref.Status.Hash
@@ -76,30 +84,15 @@ func temporalEnvVars(ctx core.Context, stack *v1beta1.Stack, payments *v1beta1.P
 		core.Env("TEMPORAL_NAMESPACE", temporalURI.Path[1:]),
 	)
 
-	if secret := temporalURI.Query().Get("secret"); secret == "" {
-		var value string
-		value, err = settings.GetStringOrEmpty(ctx, stack.Name, "temporal", "tls", "crt")
-		if err != nil {
-			return
-		}
-		env = append(env,
-			core.Env("TEMPORAL_SSL_CLIENT_CERT", value),
-		)
-
-		value, err = settings.GetStringOrEmpty(ctx, stack.Name, "temporal", "tls", "key")
-		if err != nil {
-			return
-		}
-
-		env = append(env,
-			core.Env("TEMPORAL_SSL_CLIENT_KEY", value),
-		)
-	} else {
-		env = append(env,
-			core.EnvFromSecret("TEMPORAL_SSL_CLIENT_KEY", secret, "tls.key"),
-			core.EnvFromSecret("TEMPORAL_SSL_CLIENT_CERT", secret, "tls.crt"),
-		)
+	var tls *temporal.TLS
+	tls, err = temporal.GetTLS(ctx, stack, payments, temporalURI)
+	if err != nil {
+		return
+	}
+	if tls.Hash != "" {
+		hash["temporal-tls-secret-hash"] = tls.Hash
 	}
+	env = append(env, tls.Env...)
 
 	if secret := temporalURI.Query().Get("encryptionKeySecret"); secret != "" {
 		env = append(env,
@@ -38,6 +38,9 @@ import (
 //+kubebuilder:rbac:groups=formance.com,resources=payments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=formance.com,resources=payments/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=formance.com,resources=payments/finalizers,verbs=update
+//+kubebuilder:rbac:groups=formance.com,resources=paymentsconnectors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=paymentsconnectors/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=paymentsconnectors/finalizers,verbs=update
 
 func Reconcile(ctx Context, stack *v1beta1.Stack, p *v1beta1.Payments, version string) error {
 
@@ -47,7 +50,7 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, p *v1beta1.Payments, version s
 	}
 
 	if !database.Status.Ready {
-		return NewPendingError().WithMessage("database not ready")
+		return NewPendingError().WithMessage("database not ready").OnlyOnWatchEvent()
 	}
 
 	imageName := "payments"
@@ -146,10 +149,18 @@ func init() {
 			WithOwn[*v1beta1.Payments](&batchv1.Job{}),
 			WithOwn[*v1beta1.Payments](&corev1.ConfigMap{}),
 			WithOwn[*v1beta1.Payments](&v1beta1.BenthosStream{}),
+			WithOwn[*v1beta1.Payments](&v1beta1.ResourceReference{}),
 			WithWatchSettings[*v1beta1.Payments](),
 			WithWatchDependency[*v1beta1.Payments](&v1beta1.Search{}),
 			databases.Watch[*v1beta1.Payments](),
 			brokertopics.Watch[*v1beta1.Payments]("payments"),
 		),
+		WithStackDependencyReconciler(ReconcileConnector,
+			WithFinalizer[*v1beta1.PaymentsConnector]("uninstall-connector", CleanConnector),
+			WithOwn[*v1beta1.PaymentsConnector](&v1beta1.ResourceReference{}),
+			WithWatchDependency[*v1beta1.PaymentsConnector](&v1beta1.Payments{}),
+			WithWatchDependency[*v1beta1.PaymentsConnector](&v1beta1.AuthClient{}),
+			WithRequeueAfter[*v1beta1.PaymentsConnector](connectorsRefreshInterval),
+		),
 	)
 }
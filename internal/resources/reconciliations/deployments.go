@@ -58,6 +58,11 @@ func createDeployment(
 		return err
 	}
 
+	annotations, err := authclients.GetAnnotations(ctx, authClient)
+	if err != nil {
+		return err
+	}
+
 	return applications.
 		New(reconciliation, &appsv1.Deployment{
 			ObjectMeta: metav1.ObjectMeta{
@@ -65,6 +70,9 @@ func createDeployment(
 			},
 			Spec: appsv1.DeploymentSpec{
 				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: annotations,
+					},
 					Spec: v1.PodSpec{
 						ImagePullSecrets:   imageConfiguration.PullSecrets,
 						ServiceAccountName: serviceAccountName,
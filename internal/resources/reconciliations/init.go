@@ -32,6 +32,9 @@ import (
 //+kubebuilder:rbac:groups=formance.com,resources=reconciliations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=formance.com,resources=reconciliations/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=formance.com,resources=reconciliations/finalizers,verbs=update
+//+kubebuilder:rbac:groups=formance.com,resources=reconciliationpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=reconciliationpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=reconciliationpolicies/finalizers,verbs=update
 
 func Reconcile(ctx Context, stack *v1beta1.Stack, reconciliation *v1beta1.Reconciliation, version string) error {
 	database, err := databases.Create(ctx, stack, reconciliation)
@@ -88,5 +91,11 @@ func init() {
 			WithWatchDependency[*v1beta1.Reconciliation](&v1beta1.Ledger{}),
 			WithWatchDependency[*v1beta1.Reconciliation](&v1beta1.Payments{}),
 		),
+		WithStackDependencyReconciler(ReconcilePolicy,
+			WithFinalizer[*v1beta1.ReconciliationPolicy]("delete-policy", CleanPolicy),
+			WithWatchDependency[*v1beta1.ReconciliationPolicy](&v1beta1.Reconciliation{}),
+			WithWatchDependency[*v1beta1.ReconciliationPolicy](&v1beta1.AuthClient{}),
+			WithRequeueAfter[*v1beta1.ReconciliationPolicy](policiesRefreshInterval),
+		),
 	)
 }
@@ -0,0 +1,289 @@
+package reconciliations
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+)
+
+// policiesRefreshInterval is the resolution of the schedules of the policies
+const policiesRefreshInterval = time.Minute
+
+type policyInput struct {
+	Name           string         `json:"name"`
+	LedgerName     string         `json:"ledgerName"`
+	LedgerQuery    map[string]any `json:"ledgerQuery"`
+	PaymentsPoolID string         `json:"paymentsPoolID"`
+}
+
+type policy struct {
+	policyInput
+	ID string `json:"id"`
+}
+
+type reconciliationRun struct {
+	ID                 string              `json:"id"`
+	Status             string              `json:"status"`
+	ReconciledAtLedger time.Time           `json:"reconciledAtLedger"`
+	DriftBalances      map[string]*big.Int `json:"driftBalances"`
+	Error              string              `json:"error"`
+}
+
+func ReconcilePolicy(ctx core.Context, stack *v1beta1.Stack, p *v1beta1.ReconciliationPolicy) error {
+	reconciliation := &v1beta1.Reconciliation{}
+	if err := core.GetModuleOfDependent(ctx, stack, p, reconciliation); err != nil {
+		return err
+	}
+
+	input, err := policyConfig(p)
+	if err != nil {
+		return err
+	}
+	configHash, err := hashPolicyConfig(input)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := apiclients.New(ctx, stack, reconciliation, "reconciliation:read", "reconciliation:write")
+	if err != nil {
+		return err
+	}
+
+	return reconcilePolicy(ctx, apiClient, p, input, configHash, time.Now())
+}
+
+func reconcilePolicy(ctx core.Context, apiClient *apiclients.Client, p *v1beta1.ReconciliationPolicy,
+	input policyInput, configHash string, now time.Time) error {
+
+	var schedule cron.Schedule
+	if p.Spec.Schedule != "" {
+		var err error
+		schedule, err = cron.ParseStandard(p.Spec.Schedule)
+		if err != nil {
+			return core.NewApplicationError().WithMessage("invalid schedule '%s': %s", p.Spec.Schedule, err)
+		}
+	}
+
+	if err := deletePendingPolicies(ctx, apiClient, p); err != nil {
+		return err
+	}
+
+	if p.Status.PolicyID == "" {
+		existing, err := findPolicy(ctx, apiClient, configHash)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			p.Status.PolicyID = existing.ID
+			p.Status.ConfigHash = configHash
+		}
+	} else if p.Status.ConfigHash == configHash {
+		err := apiClient.Do(ctx, http.MethodGet, policyPath(p.Status.PolicyID), nil, nil)
+		switch {
+		case apiclients.IsNotFound(err):
+			// The policy has been removed externally, create it again
+			p.Status.ConfigHash = ""
+		case err != nil:
+			return core.NewApplicationError().WithMessage("getting policy: %s", err)
+		}
+	}
+
+	if p.Status.PolicyID == "" || p.Status.ConfigHash != configHash {
+		log.FromContext(ctx).Info("Creating reconciliation policy", "name", input.Name)
+		ret := apiclients.Data[policy]{}
+		if err := apiClient.Do(ctx, http.MethodPost, "/policies", input, &ret); err != nil {
+			return core.NewApplicationError().WithMessage("creating policy: %s", err)
+		}
+
+		// The previous policy is kept in the status until its deletion succeeds
+		if p.Status.PolicyID != "" && p.Status.PolicyID != ret.Data.ID {
+			p.Status.PendingDeletion = append(p.Status.PendingDeletion, p.Status.PolicyID)
+		}
+		p.Status.PolicyID = ret.Data.ID
+		p.Status.ConfigHash = configHash
+		p.Status.LastResult = nil
+
+		if err := deletePendingPolicies(ctx, apiClient, p); err != nil {
+			return err
+		}
+	}
+
+	if schedule == nil {
+		p.Status.NextRun = nil
+		return nil
+	}
+
+	// A stored time which is not an activation of the schedule comes from a previous schedule
+	if p.Status.NextRun != nil && !schedule.Next(p.Status.NextRun.Add(-time.Second)).Equal(p.Status.NextRun.Time) {
+		p.Status.NextRun = nil
+	}
+
+	if p.Status.NextRun == nil {
+		p.Status.NextRun = &metav1.Time{Time: schedule.Next(now)}
+		return nil
+	}
+
+	if now.Before(p.Status.NextRun.Time) {
+		return nil
+	}
+
+	if err := runReconciliation(ctx, apiClient, p, now); err != nil {
+		return err
+	}
+	p.Status.NextRun = &metav1.Time{Time: schedule.Next(now)}
+
+	return nil
+}
+
+// deletePendingPolicies deletes the replaced policies, they are removed from the status once deleted
+func deletePendingPolicies(ctx core.Context, apiClient *apiclients.Client, p *v1beta1.ReconciliationPolicy) error {
+	for len(p.Status.PendingDeletion) > 0 {
+		id := p.Status.PendingDeletion[0]
+		log.FromContext(ctx).Info("Deleting previous reconciliation policy", "id", id)
+		if err := apiClient.Do(ctx, http.MethodDelete, policyPath(id), nil, nil); err != nil &&
+			!apiclients.IsNotFound(err) {
+			return core.NewApplicationError().WithMessage("deleting previous policy %s: %s", id, err)
+		}
+		p.Status.PendingDeletion = p.Status.PendingDeletion[1:]
+	}
+	p.Status.PendingDeletion = nil
+
+	return nil
+}
+
+func runReconciliation(ctx core.Context, apiClient *apiclients.Client, p *v1beta1.ReconciliationPolicy, at time.Time) error {
+	log.FromContext(ctx).Info("Running reconciliation", "policy", p.Status.PolicyID)
+	ret := apiclients.Data[reconciliationRun]{}
+	if err := apiClient.Do(ctx, http.MethodPost, policyPath(p.Status.PolicyID)+"/reconciliation", map[string]any{
+		"reconciledAtLedger":   at.UTC(),
+		"reconciledAtPayments": at.UTC(),
+	}, &ret); err != nil {
+		return core.NewApplicationError().WithMessage("running reconciliation: %s", err)
+	}
+
+	result := &v1beta1.ReconciliationResult{
+		ID:           ret.Data.ID,
+		Status:       ret.Data.Status,
+		ReconciledAt: metav1.Time{Time: ret.Data.ReconciledAtLedger},
+		Error:        ret.Data.Error,
+	}
+	if result.ReconciledAt.IsZero() {
+		result.ReconciledAt = metav1.Time{Time: at}
+	}
+	for asset, amount := range ret.Data.DriftBalances {
+		if amount == nil || amount.Sign() == 0 {
+			continue
+		}
+		if result.DriftBalances == nil {
+			result.DriftBalances = map[string]string{}
+		}
+		result.DriftBalances[asset] = amount.String()
+	}
+	p.Status.LastResult = result
+
+	return nil
+}
+
+// findPolicy allow to retrieve a policy previously created, in case the status of the object has been lost.
+func findPolicy(ctx core.Context, apiClient *apiclients.Client, configHash string) (*policy, error) {
+	query := url.Values{}
+	query.Set("pageSize", "100")
+	for {
+		ret := apiclients.Cursor[policy]{}
+		if err := apiClient.Do(ctx, http.MethodGet, "/policies?"+query.Encode(), nil, &ret); err != nil {
+			return nil, core.NewApplicationError().WithMessage("listing policies: %s", err)
+		}
+		for _, p := range ret.Cursor.Data {
+			hash, err := hashPolicyConfig(p.policyInput)
+			if err != nil {
+				return nil, err
+			}
+			if hash == configHash {
+				return &p, nil
+			}
+		}
+		if !ret.Cursor.HasMore {
+			return nil, nil
+		}
+		query = url.Values{}
+		query.Set("cursor", ret.Cursor.Next)
+	}
+}
+
+func policyConfig(p *v1beta1.ReconciliationPolicy) (policyInput, error) {
+	input := policyInput{
+		Name:           p.GetPolicyName(),
+		LedgerName:     p.Spec.LedgerName,
+		LedgerQuery:    map[string]any{},
+		PaymentsPoolID: p.Spec.PaymentsPoolID,
+	}
+	if p.Spec.LedgerQuery != nil && len(p.Spec.LedgerQuery.Raw) > 0 {
+		if err := json.Unmarshal(p.Spec.LedgerQuery.Raw, &input.LedgerQuery); err != nil {
+			return input, core.NewApplicationError().WithMessage("invalid ledger query: %s", err)
+		}
+	}
+
+	return input, nil
+}
+
+func policyPath(id string) string {
+	return fmt.Sprintf("/policies/%s", url.PathEscape(id))
+}
+
+func hashPolicyConfig(input policyInput) (string, error) {
+	if input.LedgerQuery == nil {
+		input.LedgerQuery = map[string]any{}
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(data)
+
+	return base64.StdEncoding.EncodeToString(digest[:]), nil
+}
+
+func CleanPolicy(ctx core.Context, p *v1beta1.ReconciliationPolicy) error {
+	if p.Status.PolicyID == "" && len(p.Status.PendingDeletion) == 0 {
+		return nil
+	}
+
+	reconciliation := &v1beta1.Reconciliation{}
+	stack, available, err := core.GetModuleForCleanup(ctx, p.Spec.Stack, reconciliation)
+	if err != nil || !available {
+		return err
+	}
+
+	apiClient, err := apiclients.New(ctx, stack, reconciliation, "reconciliation:read", "reconciliation:write")
+	if err != nil {
+		return err
+	}
+
+	if err := deletePendingPolicies(ctx, apiClient, p); err != nil {
+		return err
+	}
+	if p.Status.PolicyID == "" {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Deleting reconciliation policy", "id", p.Status.PolicyID)
+	if err := apiClient.Do(ctx, http.MethodDelete, policyPath(p.Status.PolicyID), nil, nil); err != nil &&
+		!apiclients.IsNotFound(err) {
+		return core.NewApplicationError().WithMessage("deleting policy: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package reconciliations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core/coretest"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients/apiclientstest"
+)
+
+type fakeReconciliationAPI struct {
+	nextID   int
+	policies map[string]policyInput
+	runs     int
+	// failDeletes makes the deletions fail
+	failDeletes bool
+}
+
+func (f *fakeReconciliationAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := apiclientstest.PathSegments(r)
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/policies":
+		data := make([]any, 0)
+		for id, input := range f.policies {
+			data = append(data, policy{policyInput: input, ID: id})
+		}
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": data}})
+	case r.Method == http.MethodPost && r.URL.Path == "/policies":
+		input := policyInput{}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.nextID++
+		id := fmt.Sprintf("policy-%d", f.nextID)
+		f.policies[id] = input
+		apiclientstest.WriteJSON(w, http.StatusCreated, map[string]any{"data": policy{policyInput: input, ID: id}})
+	case len(path) >= 2 && path[0] == "policies":
+		if _, ok := f.policies[path[1]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && len(path) == 3 && path[2] == "reconciliation":
+			f.runs++
+			body := map[string]time.Time{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+				"id":                 fmt.Sprintf("run-%d", f.runs),
+				"status":             "NOT_OK",
+				"reconciledAtLedger": body["reconciledAtLedger"],
+				"driftBalances": map[string]any{
+					"USD/2": json.Number("100000000000000000000"),
+					"EUR/2": 0,
+				},
+			}})
+		case r.Method == http.MethodDelete && f.failDeletes:
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.Method == http.MethodDelete:
+			delete(f.policies, path[1])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"data": policy{policyInput: f.policies[path[1]], ID: path[1]}})
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// policyCreatedAt is the time of the first reconciliation of the policies
+var policyCreatedAt = time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+
+type policyTest struct {
+	t         *testing.T
+	api       *fakeReconciliationAPI
+	apiClient *apiclients.Client
+	policy    *v1beta1.ReconciliationPolicy
+}
+
+func (p policyTest) tryReconcile(now time.Time) error {
+	p.t.Helper()
+	input, err := policyConfig(p.policy)
+	require.NoError(p.t, err)
+	hash, err := hashPolicyConfig(input)
+	require.NoError(p.t, err)
+	return reconcilePolicy(coretest.NewContext(), p.apiClient, p.policy, input, hash, now)
+}
+
+func (p policyTest) reconcile(now time.Time) {
+	p.t.Helper()
+	require.NoError(p.t, p.tryReconcile(now))
+}
+
+// newPolicyTest returns a test whose policy has already been created on the fake API
+func newPolicyTest(t *testing.T) policyTest {
+	t.Helper()
+
+	api := &fakeReconciliationAPI{
+		policies: map[string]policyInput{},
+	}
+	ret := policyTest{
+		t:         t,
+		api:       api,
+		apiClient: apiclientstest.NewClient(t, api),
+		policy: &v1beta1.ReconciliationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "users",
+			},
+			Spec: v1beta1.ReconciliationPolicySpec{
+				LedgerName:     "default",
+				LedgerQuery:    &apiextensionsv1.JSON{Raw: []byte(`{"$match": {"address": "users:"}}`)},
+				PaymentsPoolID: "pool-1",
+				Schedule:       "0 * * * *",
+			},
+		},
+	}
+	ret.reconcile(policyCreatedAt)
+
+	return ret
+}
+
+func TestReconcilePolicyCreate(t *testing.T) {
+	t.Parallel()
+
+	test := newPolicyTest(t)
+
+	// The first run is scheduled on the next hour
+	policyID := test.policy.Status.PolicyID
+	require.Contains(t, test.api.policies, policyID)
+	require.Equal(t, "users", test.api.policies[policyID].Name)
+	require.Equal(t, map[string]any{"address": "users:"}, test.api.policies[policyID].LedgerQuery["$match"])
+	require.Equal(t, time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), test.policy.Status.NextRun.Time)
+	require.Nil(t, test.policy.Status.LastResult)
+}
+
+func TestReconcilePolicySchedule(t *testing.T) {
+	t.Parallel()
+
+	test := newPolicyTest(t)
+
+	// Not yet scheduled
+	test.reconcile(policyCreatedAt.Add(10 * time.Minute))
+	require.Equal(t, 0, test.api.runs)
+
+	// Scheduled
+	test.reconcile(policyCreatedAt.Add(31 * time.Minute))
+	require.Equal(t, 1, test.api.runs)
+	require.NotNil(t, test.policy.Status.LastResult)
+	require.Equal(t, "NOT_OK", test.policy.Status.LastResult.Status)
+	require.Equal(t, map[string]string{"USD/2": "100000000000000000000"}, test.policy.Status.LastResult.DriftBalances)
+	require.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), test.policy.Status.NextRun.Time)
+}
+
+func TestReconcilePolicyScheduleUpdate(t *testing.T) {
+	t.Parallel()
+
+	test := newPolicyTest(t)
+	test.policy.Spec.Schedule = "0 0 1 * *"
+	test.reconcile(policyCreatedAt)
+	require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), test.policy.Status.NextRun.Time)
+
+	// The next run of the previous schedule is not waited for
+	test.policy.Spec.Schedule = "45 * * * *"
+	test.reconcile(policyCreatedAt)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC), test.policy.Status.NextRun.Time)
+	require.Equal(t, 0, test.api.runs)
+}
+
+func TestReconcilePolicyStatusLost(t *testing.T) {
+	t.Parallel()
+
+	test := newPolicyTest(t)
+	policyID := test.policy.Status.PolicyID
+
+	// A new policy is not created
+	test.policy.Status = v1beta1.ReconciliationPolicyStatus{}
+	test.reconcile(policyCreatedAt)
+	require.Equal(t, policyID, test.policy.Status.PolicyID)
+	require.Len(t, test.api.policies, 1)
+}
+
+func TestReconcilePolicyUpdate(t *testing.T) {
+	t.Parallel()
+
+	test := newPolicyTest(t)
+	policyID := test.policy.Status.PolicyID
+
+	// The policy is replaced
+	test.policy.Spec.PaymentsPoolID = "pool-2"
+	test.reconcile(policyCreatedAt)
+	require.NotEqual(t, policyID, test.policy.Status.PolicyID)
+	require.Len(t, test.api.policies, 1)
+	require.Equal(t, "pool-2", test.api.policies[test.policy.Status.PolicyID].PaymentsPoolID)
+}
+
+func TestReconcilePolicyDeletionRetried(t *testing.T) {
+	t.Parallel()
+
+	test := newPolicyTest(t)
+	policyID := test.policy.Status.PolicyID
+
+	test.api.failDeletes = true
+	test.policy.Spec.PaymentsPoolID = "pool-2"
+	require.Error(t, test.tryReconcile(policyCreatedAt))
+	require.Equal(t, []string{policyID}, test.policy.Status.PendingDeletion)
+	require.Len(t, test.api.policies, 2)
+
+	test.api.failDeletes = false
+	test.reconcile(policyCreatedAt)
+	require.Empty(t, test.policy.Status.PendingDeletion)
+	require.Len(t, test.api.policies, 1)
+	require.Contains(t, test.api.policies, test.policy.Status.PolicyID)
+}
+
+func TestReconcilePolicyInvalidSchedule(t *testing.T) {
+	t.Parallel()
+
+	test := newPolicyTest(t)
+
+	test.policy.Spec.Schedule = "invalid"
+	require.Error(t, test.tryReconcile(policyCreatedAt))
+}
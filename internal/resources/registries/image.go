@@ -54,3 +54,13 @@ func GetCaddyImage(ctx core.Context, stack *v1beta1.Stack) (*ImageConfiguration,
 
 	return GetImageConfiguration(ctx, stack.Name, selectedCaddyImage)
 }
+
+func GetPostgresImage(ctx core.Context, stack *v1beta1.Stack) (*ImageConfiguration, error) {
+	defaultPostgresImage := "postgres:16-alpine"
+	selectedPostgresImage, err := settings.GetStringOrDefault(ctx, stack.Name, defaultPostgresImage, "databases", "clone", "image")
+	if err != nil {
+		return nil, err
+	}
+
+	return GetImageConfiguration(ctx, stack.Name, selectedPostgresImage)
+}
@@ -3,7 +3,9 @@ package resourcereferences
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -54,3 +56,44 @@ func Delete(ctx core.Context, owner v1beta1.Dependent, name string) error {
 	}
 	return nil
 }
+
+// GetSecret copies a secret in the namespace of the stack of the owner using a ResourceReference, and returns the copy.
+// The copy is made asynchronously, a pending error is returned until it is available.
+func GetSecret(ctx core.Context, owner v1beta1.Dependent, name, secretName string) (*corev1.Secret, error) {
+	if _, err := Create(ctx, owner, name, secretName, &corev1.Secret{}); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Namespace: owner.GetStack(),
+		Name:      secretName,
+	}, secret); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, core.NewPendingError().WithMessage("waiting for secret %s to be copied in the stack namespace", secretName)
+		}
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// FindSecret returns the source secret matching the given name, as resolved by ResourceReferences:
+// it is searched in all namespaces among the secrets labeled for the stack (or for any stack),
+// using the rewritten name annotation when defined. The copy in the stack namespace is not used.
+func FindSecret(ctx core.Context, stack, name string) (*corev1.Secret, error) {
+	resource, err := findMatchingResource(ctx, stack, metav1.GroupVersionKind{
+		Version: "v1",
+		Kind:    "Secret",
+	}, name)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.UnstructuredContent(), secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,354 @@
+package stackclones
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+)
+
+//+kubebuilder:rbac:groups=formance.com,resources=stackclones,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=stackclones/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=stackclones/finalizers,verbs=update
+
+// ClonedByAnnotation is set on the objects created for the destination stack, with the name of the StackClone
+const ClonedByAnnotation = "formance.com/cloned-by"
+
+func Reconcile(ctx Context, clone *v1beta1.StackClone) error {
+	if clone.Status.CompletedAt != nil {
+		return nil
+	}
+
+	source := &v1beta1.Stack{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: clone.Spec.Source,
+	}, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return NewApplicationError().WithMessage("source stack '%s' not found", clone.Spec.Source)
+		}
+		return err
+	}
+
+	if err := create(ctx, clone, &v1beta1.Stack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   clone.Spec.Destination,
+			Labels: source.Labels,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}); err != nil {
+		return err
+	}
+
+	if err := cloneSettings(ctx, clone); err != nil {
+		return err
+	}
+
+	// The namespace is created by the stack reconciler, it is required to copy the secrets and the databases
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: clone.Spec.Destination,
+	}, &corev1.Namespace{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return NewPendingError().WithMessage("waiting for namespace '%s'", clone.Spec.Destination)
+		}
+		return err
+	}
+
+	if err := cloneSecrets(ctx, clone); err != nil {
+		return err
+	}
+
+	// Databases are created before the modules, so the modules use them instead of creating empty ones
+	databases := make([]string, 0)
+	if clone.Spec.CopyDatabases {
+		var err error
+		databases, err = cloneDatabases(ctx, clone)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cloneResources(ctx, clone); err != nil {
+		return err
+	}
+
+	for _, name := range databases {
+		database := &v1beta1.Database{}
+		if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+			Name: name,
+		}, database); err != nil {
+			return err
+		}
+		if !database.Status.Ready {
+			return NewPendingError().WithMessage("waiting for database '%s' to be copied", name)
+		}
+	}
+
+	now := metav1.Now()
+	clone.Status.CompletedAt = &now
+
+	return nil
+}
+
+// GetClonedName returns the name of an object of the source stack for the destination stack
+func GetClonedName(clone *v1beta1.StackClone, name string) string {
+	if name == clone.Spec.Source {
+		return clone.Spec.Destination
+	}
+	if strings.HasPrefix(name, clone.Spec.Source+"-") {
+		return clone.Spec.Destination + strings.TrimPrefix(name, clone.Spec.Source)
+	}
+	return GetObjectName(clone.Spec.Destination, name)
+}
+
+// create creates an object for the destination stack.
+// Objects already created by the clone are left untouched, as they may have been modified since.
+func create(ctx Context, clone *v1beta1.StackClone, object client.Object) error {
+	gvk, err := apiutil.GVKForObject(object, ctx.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ClonedByAnnotation] = clone.Name
+	object.SetAnnotations(annotations)
+
+	if err := ctx.GetClient().Create(ctx, object); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+
+		existing := object.DeepCopyObject().(client.Object)
+		if err := ctx.GetClient().Get(ctx, client.ObjectKeyFromObject(object), existing); err != nil {
+			return err
+		}
+		if existing.GetAnnotations()[ClonedByAnnotation] != clone.Name {
+			return NewApplicationError().WithMessage("%s '%s' already exists", gvk.Kind, object.GetName())
+		}
+	}
+
+	name := fmt.Sprintf("%s/%s", gvk.Kind, object.GetName())
+	if object.GetNamespace() != "" {
+		name = fmt.Sprintf("%s/%s/%s", gvk.Kind, object.GetNamespace(), object.GetName())
+	}
+	if !slices.Contains(clone.Status.ClonedResources, name) {
+		clone.Status.ClonedResources = append(clone.Status.ClonedResources, name)
+	}
+
+	return nil
+}
+
+// cloneSettings duplicates the settings explicitly targeting the source stack.
+// Settings using a wildcard already apply to the destination stack.
+func cloneSettings(ctx Context, clone *v1beta1.StackClone) error {
+	settingsList := &v1beta1.SettingsList{}
+	if err := ctx.GetClient().List(ctx, settingsList, client.MatchingFields{
+		"stack": clone.Spec.Source,
+	}); err != nil {
+		return err
+	}
+
+	for _, settings := range settingsList.Items {
+		if err := create(ctx, clone, &v1beta1.Settings{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   GetClonedName(clone, settings.Name),
+				Labels: settings.Labels,
+			},
+			Spec: v1beta1.SettingsSpec{
+				Stacks: []string{clone.Spec.Destination},
+				Key:    settings.Spec.Key,
+				Value:  settings.Spec.Value,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneSecrets copies the secrets created by the user in the namespace of the source stack.
+// Secrets owned by an object are managed by the operator and are recreated for the destination stack.
+func cloneSecrets(ctx Context, clone *v1beta1.StackClone) error {
+	secrets := &corev1.SecretList{}
+	if err := ctx.GetClient().List(ctx, secrets, client.InNamespace(clone.Spec.Source)); err != nil {
+		return err
+	}
+
+	for _, secret := range secrets.Items {
+		if len(secret.OwnerReferences) > 0 || secret.Type != corev1.SecretTypeOpaque {
+			continue
+		}
+
+		if err := create(ctx, clone, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: clone.Spec.Destination,
+				Name:      secret.Name,
+				Labels:    secret.Labels,
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneDatabases creates the databases of the destination stack, with the database of the source stack to copy
+func cloneDatabases(ctx Context, clone *v1beta1.StackClone) ([]string, error) {
+	databases := make([]*v1beta1.Database, 0)
+	if err := GetAllStackDependencies(ctx, clone.Spec.Source, &databases); err != nil {
+		return nil, err
+	}
+
+	ret := make([]string, 0, len(databases))
+	for _, database := range databases {
+		name := GetObjectName(clone.Spec.Destination, database.Spec.Service)
+		if err := create(ctx, clone, &v1beta1.Database{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1beta1.DatabaseSpec{
+				StackDependency: v1beta1.StackDependency{
+					Stack: clone.Spec.Destination,
+				},
+				Service:   database.Spec.Service,
+				Debug:     database.Spec.Debug,
+				CloneFrom: database.Name,
+			},
+		}); err != nil {
+			return nil, err
+		}
+		ret = append(ret, name)
+	}
+
+	return ret, nil
+}
+
+// cloneResources duplicates the modules and the resources declared by the user on the source stack.
+// Objects controlled by another object are created by the operator, and are recreated by the reconcilers
+// of the destination stack.
+func cloneResources(ctx Context, clone *v1beta1.StackClone) error {
+	kinds := make([]string, 0)
+	for kind, rtype := range ctx.GetScheme().KnownTypes(v1beta1.GroupVersion) {
+		if _, ok := reflect.New(rtype).Interface().(v1beta1.Dependent); ok {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(v1beta1.GroupVersion.WithKind(kind))
+		if err := ctx.GetClient().List(ctx, list, client.MatchingFields{
+			"stack": clone.Spec.Source,
+		}); err != nil {
+			return err
+		}
+
+		for _, item := range list.Items {
+			if metav1.GetControllerOf(&item) != nil {
+				continue
+			}
+
+			object, err := cloneResource(clone, &item)
+			if err != nil {
+				return err
+			}
+
+			if err := create(ctx, clone, object); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func cloneResource(clone *v1beta1.StackClone, item *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	spec, _, err := unstructured.NestedMap(item.Object, "spec")
+	if err != nil {
+		return nil, err
+	}
+	spec["stack"] = clone.Spec.Destination
+
+	if clone.Spec.RegenerateAuthSecrets {
+		switch item.GetKind() {
+		case "AuthClient":
+			if _, ok := spec["secret"]; ok {
+				spec["secret"] = uuid.NewString()
+			}
+			if _, ok := spec["secretFromSecret"]; ok {
+				delete(spec, "secretFromSecret")
+				spec["secret"] = uuid.NewString()
+			}
+		case "Auth":
+			// Without signing key, a new one is generated by the operator
+			delete(spec, "signingKey")
+			delete(spec, "signingKeyFromSecret")
+		}
+	}
+
+	annotations := item.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+
+	ret := &unstructured.Unstructured{
+		Object: map[string]any{
+			"spec": spec,
+		},
+	}
+	ret.SetGroupVersionKind(item.GroupVersionKind())
+	ret.SetName(GetClonedName(clone, item.GetName()))
+	ret.SetLabels(item.GetLabels())
+	ret.SetAnnotations(annotations)
+
+	return ret, nil
+}
+
+func getStackClones(ctx Context, stack string) []reconcile.Request {
+	clones := &v1beta1.StackCloneList{}
+	if err := ctx.GetClient().List(ctx, clones); err != nil {
+		return []reconcile.Request{}
+	}
+
+	ret := make([]*v1beta1.StackClone, 0)
+	for i := range clones.Items {
+		clone := &clones.Items[i]
+		if clone.Status.CompletedAt == nil && (clone.Spec.Source == stack || clone.Spec.Destination == stack) {
+			ret = append(ret, clone)
+		}
+	}
+
+	return MapObjectToReconcileRequests(ret...)
+}
+
+func init() {
+	Init(
+		WithStdReconciler(Reconcile,
+			WithWatch[*v1beta1.StackClone, *v1beta1.Stack](func(ctx Context, stack *v1beta1.Stack) []reconcile.Request {
+				return getStackClones(ctx, stack.Name)
+			}),
+			WithWatch[*v1beta1.StackClone, *v1beta1.Database](func(ctx Context, database *v1beta1.Database) []reconcile.Request {
+				return getStackClones(ctx, database.Spec.Stack)
+			}),
+		),
+	)
+}
@@ -0,0 +1,99 @@
+package stackclones
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestGetClonedName(t *testing.T) {
+	t.Parallel()
+
+	clone := &v1beta1.StackClone{
+		Spec: v1beta1.StackCloneSpec{
+			Source:      "production",
+			Destination: "staging",
+		},
+	}
+
+	require.Equal(t, "staging", GetClonedName(clone, "production"))
+	require.Equal(t, "staging-ledger", GetClonedName(clone, "production-ledger"))
+	require.Equal(t, "staging-productionledger", GetClonedName(clone, "productionledger"))
+	require.Equal(t, "staging-my-client", GetClonedName(clone, "my-client"))
+}
+
+func TestCloneResource(t *testing.T) {
+	t.Parallel()
+
+	clone := &v1beta1.StackClone{
+		Spec: v1beta1.StackCloneSpec{
+			Source:      "production",
+			Destination: "staging",
+		},
+	}
+
+	authClient := &unstructured.Unstructured{
+		Object: map[string]any{
+			"spec": map[string]any{
+				"stack":  "production",
+				"id":     "client",
+				"secret": "secret",
+			},
+			"status": map[string]any{
+				"ready": true,
+			},
+		},
+	}
+	authClient.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("AuthClient"))
+	authClient.SetName("production-client")
+	authClient.SetAnnotations(map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		"foo": "bar",
+	})
+	authClient.SetUID("uid")
+
+	ret, err := cloneResource(clone, authClient)
+	require.NoError(t, err)
+	require.Equal(t, "staging-client", ret.GetName())
+	require.Equal(t, authClient.GroupVersionKind(), ret.GroupVersionKind())
+	require.Equal(t, map[string]string{"foo": "bar"}, ret.GetAnnotations())
+	require.Empty(t, ret.GetUID())
+	require.NotContains(t, ret.Object, "status")
+	require.Equal(t, map[string]any{
+		"stack":  "staging",
+		"id":     "client",
+		"secret": "secret",
+	}, ret.Object["spec"])
+
+	// The secret is regenerated on demand
+	clone.Spec.RegenerateAuthSecrets = true
+	ret, err = cloneResource(clone, authClient)
+	require.NoError(t, err)
+	spec := ret.Object["spec"].(map[string]any)
+	require.NotEqual(t, "secret", spec["secret"])
+	require.NotEmpty(t, spec["secret"])
+
+	// The source object is not modified
+	require.Equal(t, "production", authClient.Object["spec"].(map[string]any)["stack"])
+
+	auth := &unstructured.Unstructured{
+		Object: map[string]any{
+			"spec": map[string]any{
+				"stack":      "production",
+				"signingKey": "key",
+			},
+		},
+	}
+	auth.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("Auth"))
+	auth.SetName("production")
+
+	ret, err = cloneResource(clone, auth)
+	require.NoError(t, err)
+	require.Equal(t, "staging", ret.GetName())
+	require.Equal(t, map[string]any{
+		"stack": "staging",
+	}, ret.Object["spec"])
+}
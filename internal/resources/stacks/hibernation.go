@@ -0,0 +1,115 @@
+package stacks
+
+import (
+	"time"
+
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+)
+
+// hibernationScheduleRefreshInterval is the resolution of the hibernation schedules
+const hibernationScheduleRefreshInterval = time.Minute
+
+// reconcileHibernationSchedule hibernates and wakes up the stack according to the settings
+// `hibernation.schedule.hibernate` and `hibernation.schedule.wake`
+func reconcileHibernationSchedule(ctx Context, stack *v1beta1.Stack) error {
+	hibernateSchedule, err := settings.GetStringOrEmpty(ctx, stack.Name, "hibernation", "schedule", "hibernate")
+	if err != nil {
+		return err
+	}
+
+	wakeSchedule, err := settings.GetStringOrEmpty(ctx, stack.Name, "hibernation", "schedule", "wake")
+	if err != nil {
+		return err
+	}
+
+	hibernated, err := applyHibernationSchedule(stack, hibernateSchedule, wakeSchedule, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if hibernated == stack.Spec.Hibernated {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Update stack hibernation according to schedule", "hibernated", hibernated)
+	patch := client.MergeFrom(stack.DeepCopy())
+	stack.Spec.Hibernated = hibernated
+
+	return ctx.GetClient().Patch(ctx, stack, patch)
+}
+
+// applyHibernationSchedule computes the next hibernation and wake up of the stack, and returns
+// whether the stack must be hibernated.
+// The stack is only updated when a scheduled time is reached, so it can still be hibernated or woken up manually in between.
+func applyHibernationSchedule(stack *v1beta1.Stack, hibernateSchedule, wakeSchedule string, now time.Time) (bool, error) {
+	if hibernateSchedule == "" && wakeSchedule == "" {
+		stack.Status.Hibernation = nil
+		return stack.Spec.Hibernated, nil
+	}
+
+	if stack.Status.Hibernation == nil {
+		stack.Status.Hibernation = &v1beta1.StackHibernationStatus{}
+	}
+
+	lastHibernation, err := applySchedule(&stack.Status.Hibernation.NextHibernation, hibernateSchedule, now)
+	if err != nil {
+		return false, NewApplicationError().WithMessage("invalid hibernation schedule '%s': %s", hibernateSchedule, err)
+	}
+
+	lastWakeUp, err := applySchedule(&stack.Status.Hibernation.NextWakeUp, wakeSchedule, now)
+	if err != nil {
+		return false, NewApplicationError().WithMessage("invalid wake up schedule '%s': %s", wakeSchedule, err)
+	}
+
+	switch {
+	case lastHibernation == nil && lastWakeUp == nil:
+		return stack.Spec.Hibernated, nil
+	case lastWakeUp == nil:
+		return true, nil
+	case lastHibernation == nil:
+		return false, nil
+	default:
+		// Both have been reached since the last reconciliation, the last one wins
+		return lastHibernation.After(*lastWakeUp), nil
+	}
+}
+
+// applySchedule updates next with the next activation of the schedule.
+// It returns the previous value of next if it has been reached.
+func applySchedule(next **metav1.Time, expression string, now time.Time) (*time.Time, error) {
+	if expression == "" {
+		*next = nil
+		return nil, nil
+	}
+
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	// A stored time which is not an activation of the schedule comes from a previous schedule
+	if *next != nil && !schedule.Next((*next).Add(-time.Second)).Equal((*next).Time) {
+		*next = nil
+	}
+
+	if *next == nil {
+		*next = &metav1.Time{Time: schedule.Next(now)}
+		return nil, nil
+	}
+
+	if now.Before((*next).Time) {
+		return nil, nil
+	}
+
+	reached := (*next).Time
+	*next = &metav1.Time{Time: schedule.Next(now)}
+
+	return &reached, nil
+}
@@ -0,0 +1,102 @@
+package stacks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func TestApplyHibernationSchedule(t *testing.T) {
+	t.Parallel()
+
+	const (
+		hibernateSchedule = "0 20 * * *"
+		wakeSchedule      = "0 8 * * *"
+	)
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	stack := &v1beta1.Stack{}
+
+	// First reconciliation only computes the schedule
+	hibernated, err := applyHibernationSchedule(stack, hibernateSchedule, wakeSchedule, at(12, 0))
+	require.NoError(t, err)
+	require.False(t, hibernated)
+	require.Equal(t, at(20, 0), stack.Status.Hibernation.NextHibernation.Time)
+	require.Equal(t, at(32, 0), stack.Status.Hibernation.NextWakeUp.Time)
+
+	// Nothing reached
+	hibernated, err = applyHibernationSchedule(stack, hibernateSchedule, wakeSchedule, at(19, 59))
+	require.NoError(t, err)
+	require.False(t, hibernated)
+
+	// Hibernation reached
+	hibernated, err = applyHibernationSchedule(stack, hibernateSchedule, wakeSchedule, at(20, 0))
+	require.NoError(t, err)
+	require.True(t, hibernated)
+	require.Equal(t, at(44, 0), stack.Status.Hibernation.NextHibernation.Time)
+	stack.Spec.Hibernated = true
+
+	// Manually woken up, the schedule does not hibernate the stack again before the next activation
+	stack.Spec.Hibernated = false
+	hibernated, err = applyHibernationSchedule(stack, hibernateSchedule, wakeSchedule, at(22, 0))
+	require.NoError(t, err)
+	require.False(t, hibernated)
+
+	// Both reached (operator down for example), the last one wins
+	hibernated, err = applyHibernationSchedule(stack, hibernateSchedule, wakeSchedule, at(45, 0))
+	require.NoError(t, err)
+	require.True(t, hibernated)
+	stack.Spec.Hibernated = true
+
+	// Wake up reached
+	hibernated, err = applyHibernationSchedule(stack, hibernateSchedule, wakeSchedule, at(56, 0))
+	require.NoError(t, err)
+	require.False(t, hibernated)
+	stack.Spec.Hibernated = false
+
+	// Schedule changed, the next activation is computed again
+	hibernated, err = applyHibernationSchedule(stack, "0 21 * * *", wakeSchedule, at(57, 0))
+	require.NoError(t, err)
+	require.False(t, hibernated)
+	require.Equal(t, at(69, 0), stack.Status.Hibernation.NextHibernation.Time)
+
+	// Wake up schedule removed
+	_, err = applyHibernationSchedule(stack, "0 21 * * *", "", at(57, 0))
+	require.NoError(t, err)
+	require.Nil(t, stack.Status.Hibernation.NextWakeUp)
+
+	// Schedules removed
+	stack.Spec.Hibernated = true
+	hibernated, err = applyHibernationSchedule(stack, "", "", at(58, 0))
+	require.NoError(t, err)
+	require.True(t, hibernated)
+	require.Nil(t, stack.Status.Hibernation)
+
+	// Invalid schedule
+	_, err = applyHibernationSchedule(stack, "invalid", "", at(58, 0))
+	require.Error(t, err)
+}
+
+func TestApplyHibernationScheduleKeepsActivation(t *testing.T) {
+	t.Parallel()
+
+	next := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	stack := &v1beta1.Stack{
+		Status: v1beta1.StackStatus{
+			Hibernation: &v1beta1.StackHibernationStatus{
+				NextHibernation: &metav1.Time{Time: next},
+			},
+		},
+	}
+
+	_, err := applyHibernationSchedule(stack, "0 20 * * *", "", next.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, next, stack.Status.Hibernation.NextHibernation.Time)
+}
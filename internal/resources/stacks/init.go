@@ -10,8 +10,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -20,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -30,6 +33,7 @@ import (
 	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	. "github.com/formancehq/operator/v3/internal/core"
 	"github.com/formancehq/operator/v3/internal/resources/settings"
+	"github.com/formancehq/operator/v3/internal/resources/temporal"
 )
 
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
@@ -44,6 +48,8 @@ import (
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=limitranges,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=formance.com,resources=stacks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=formance.com,resources=stacks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=formance.com,resources=stacks/finalizers,verbs=update
@@ -56,6 +62,12 @@ var (
 )
 
 func setModulesCondition(ctx Context, stack *v1beta1.Stack) error {
+	objects, err := listStackObjects(ctx, stack)
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]v1beta1.ModuleSummary, 0)
 	for _, rtype := range ctx.GetScheme().AllKnownTypes() {
 		v := reflect.New(rtype).Interface()
 		r, ok := v.(v1beta1.Module)
@@ -126,8 +138,24 @@ func setModulesCondition(ctx Context, stack *v1beta1.Stack) error {
 			condition.SetMessage("All checks passed")
 
 		}()
+
+		module := reflect.New(rtype).Interface().(v1beta1.Module)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(l.Items[0].UnstructuredContent(), module); err != nil {
+			return err
+		}
+		info, _, _ := unstructured.NestedString(l.Items[0].Object, "status", "info")
+		summary, err := getModuleSummary(ctx, stack, module, gvk.Kind, info, objects)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, *summary)
 	}
 
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Kind < summaries[j].Kind
+	})
+	stack.Status.Summary = summaries
+
 	modules := make([]string, 0)
 	pendingModules := make([]string, 0)
 	for _, condition := range stack.Status.Conditions {
@@ -143,7 +171,7 @@ func setModulesCondition(ctx Context, stack *v1beta1.Stack) error {
 	sort.Strings(modules)
 	stack.Status.Modules = modules
 	if len(pendingModules) > 0 {
-		return NewPendingError().WithMessage("Pending modules: %s", modules)
+		return NewPendingError().WithMessage("Pending modules: %s", pendingModules)
 	}
 
 	return nil
@@ -220,6 +248,18 @@ func Reconcile(ctx Context, stack *v1beta1.Stack) error {
 		return err
 	}
 
+	if err := reconcileResourceQuota(ctx, stack); err != nil {
+		return err
+	}
+
+	if err := reconcileLimitRange(ctx, stack); err != nil {
+		return err
+	}
+
+	if err := reconcileHibernationSchedule(ctx, stack); err != nil {
+		return err
+	}
+
 	if err := setModulesCondition(ctx, stack); err != nil {
 		return err
 	}
@@ -253,6 +293,10 @@ func Clean(ctx Context, t *v1beta1.Stack) error {
 
 	logger.Info("All dependencies removed")
 
+	if err := temporal.DeleteNamespace(ctx, t); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -375,6 +419,8 @@ func init() {
 		WithStdReconciler(Reconcile,
 			WithOwn[*v1beta1.Stack](&corev1.Namespace{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})),
 			WithOwn[*v1beta1.Stack](&networkingv1.NetworkPolicy{}),
+			WithOwn[*v1beta1.Stack](&corev1.ResourceQuota{}),
+			WithOwn[*v1beta1.Stack](&corev1.LimitRange{}),
 			WithRaw[*v1beta1.Stack](func(ctx Context, b *builder.Builder) error {
 				for _, rtype := range ctx.GetScheme().AllKnownTypes() {
 					v := reflect.New(rtype).Interface()
@@ -423,11 +469,34 @@ func init() {
 
 				return nil
 			}),
+			WithRaw[*v1beta1.Stack](func(ctx Context, b *builder.Builder) error {
+				// Refresh the summary of the modules when the replicas of a deployment of the stack change
+				b.Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(
+					func(watchCtx context.Context, object client.Object) []reconcile.Request {
+						return []reconcile.Request{{
+							NamespacedName: types.NamespacedName{
+								Name: object.GetNamespace(),
+							},
+						}}
+					},
+				), builder.WithPredicates(predicate.Funcs{
+					UpdateFunc: func(e event.UpdateEvent) bool {
+						oldDeployment := e.ObjectOld.(*appsv1.Deployment)
+						newDeployment := e.ObjectNew.(*appsv1.Deployment)
+						return oldDeployment.Status.AvailableReplicas != newDeployment.Status.AvailableReplicas ||
+							!equality.Semantic.DeepEqual(oldDeployment.Spec.Replicas, newDeployment.Spec.Replicas)
+					},
+				}))
+				return nil
+			}),
 			WithRaw[*v1beta1.Stack](func(ctx Context, b *builder.Builder) error {
 				b.Watches(&v1beta1.Settings{}, handler.EnqueueRequestsFromMapFunc(
 					func(watchCtx context.Context, object client.Object) []reconcile.Request {
 						s := object.(*v1beta1.Settings)
-						if s.Spec.Key != "networkpolicies.enabled" {
+						if s.Spec.Key != "networkpolicies.enabled" &&
+							!strings.HasPrefix(s.Spec.Key, "hibernation.schedule.") &&
+							!strings.HasPrefix(s.Spec.Key, "namespace.resource-quota.") &&
+							!strings.HasPrefix(s.Spec.Key, "namespace.limit-range.") {
 							return nil
 						}
 						requests := make([]reconcile.Request, 0)
@@ -453,6 +522,7 @@ func init() {
 				))
 				return nil
 			}),
+			WithRequeueAfter[*v1beta1.Stack](hibernationScheduleRefreshInterval),
 			// notes(gfyrag): Some resources need to be properly dropped before the stack is dropped
 			WithFinalizer("delete", Clean),
 		),
@@ -0,0 +1,128 @@
+package stacks
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+)
+
+const (
+	resourceQuotaName = "stack-resource-quota"
+	limitRangeName    = "stack-limit-range"
+)
+
+// reconcileResourceQuota limits the total footprint of the stack using the settings `namespace.resource-quota.*`.
+// The usage of the quota is reported in the status of the stack.
+func reconcileResourceQuota(ctx Context, stack *v1beta1.Stack) error {
+	hard, err := getResourceQuotaHard(ctx, stack.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(hard) == 0 {
+		stack.Status.ResourceQuota = nil
+		return DeleteIfExists[*corev1.ResourceQuota](ctx, types.NamespacedName{
+			Namespace: stack.Name,
+			Name:      resourceQuotaName,
+		})
+	}
+
+	resourceQuota, _, err := CreateOrUpdate[*corev1.ResourceQuota](ctx,
+		types.NamespacedName{
+			Namespace: stack.Name,
+			Name:      resourceQuotaName,
+		},
+		func(t *corev1.ResourceQuota) error {
+			t.Spec.Hard = hard
+			return nil
+		},
+		WithController[*corev1.ResourceQuota](ctx.GetScheme(), stack),
+	)
+	if err != nil {
+		return err
+	}
+
+	stack.Status.ResourceQuota = resourceQuota.Status.DeepCopy()
+
+	return nil
+}
+
+// getResourceQuotaHard builds the hard limits of the quota.
+// `limits` and `requests` are resource lists like the ones used for the resource requirements of the containers,
+// `hard` allow to specify any other limit supported by a ResourceQuota (pods, services, ...).
+func getResourceQuotaHard(ctx Context, stack string) (corev1.ResourceList, error) {
+	resourceRequirements, err := settings.GetResourceRequirements(ctx, stack, "namespace", "resource-quota")
+	if err != nil {
+		return nil, err
+	}
+
+	hard, err := settings.GetResourceList(ctx, stack, "namespace", "resource-quota", "hard")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := corev1.ResourceList{}
+	for name, quantity := range hard {
+		ret[name] = quantity
+	}
+	for name, quantity := range resourceRequirements.Limits {
+		ret[corev1.ResourceName(fmt.Sprintf("limits.%s", name))] = quantity
+	}
+	for name, quantity := range resourceRequirements.Requests {
+		ret[corev1.ResourceName(fmt.Sprintf("requests.%s", name))] = quantity
+	}
+
+	return ret, nil
+}
+
+// reconcileLimitRange configures the default resources and the bounds of the containers of the stack
+// using the settings `namespace.limit-range.*`.
+func reconcileLimitRange(ctx Context, stack *v1beta1.Stack) error {
+	resourceRequirements, err := settings.GetResourceRequirements(ctx, stack.Name, "namespace", "limit-range")
+	if err != nil {
+		return err
+	}
+
+	maxResources, err := settings.GetResourceList(ctx, stack.Name, "namespace", "limit-range", "max")
+	if err != nil {
+		return err
+	}
+
+	minResources, err := settings.GetResourceList(ctx, stack.Name, "namespace", "limit-range", "min")
+	if err != nil {
+		return err
+	}
+
+	if len(resourceRequirements.Limits) == 0 && len(resourceRequirements.Requests) == 0 &&
+		len(maxResources) == 0 && len(minResources) == 0 {
+		return DeleteIfExists[*corev1.LimitRange](ctx, types.NamespacedName{
+			Namespace: stack.Name,
+			Name:      limitRangeName,
+		})
+	}
+
+	_, _, err = CreateOrUpdate[*corev1.LimitRange](ctx,
+		types.NamespacedName{
+			Namespace: stack.Name,
+			Name:      limitRangeName,
+		},
+		func(t *corev1.LimitRange) error {
+			t.Spec.Limits = []corev1.LimitRangeItem{{
+				Type:           corev1.LimitTypeContainer,
+				Default:        resourceRequirements.Limits,
+				DefaultRequest: resourceRequirements.Requests,
+				Max:            maxResources,
+				Min:            minResources,
+			}}
+			return nil
+		},
+		WithController[*corev1.LimitRange](ctx.GetScheme(), stack),
+	)
+
+	return err
+}
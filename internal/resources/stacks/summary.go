@@ -0,0 +1,149 @@
+package stacks
+
+import (
+	"errors"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	. "github.com/formancehq/operator/v3/internal/core"
+)
+
+// stackObjects holds the objects of a stack used to build the summary of its modules
+type stackObjects struct {
+	deployments     []appsv1.Deployment
+	databases       []v1beta1.Database
+	brokerConsumers []v1beta1.BrokerConsumer
+	broker          *v1beta1.Broker
+}
+
+func listStackObjects(ctx Context, stack *v1beta1.Stack) (*stackObjects, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := ctx.GetClient().List(ctx, deployments, client.InNamespace(stack.Name)); err != nil {
+		return nil, err
+	}
+
+	databases := &v1beta1.DatabaseList{}
+	if err := ctx.GetClient().List(ctx, databases, client.MatchingFields{
+		"stack": stack.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	brokerConsumers := &v1beta1.BrokerConsumerList{}
+	if err := ctx.GetClient().List(ctx, brokerConsumers, client.MatchingFields{
+		"stack": stack.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	broker := &v1beta1.Broker{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: stack.Name,
+	}, broker); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		broker = nil
+	}
+
+	return &stackObjects{
+		deployments:     deployments.Items,
+		databases:       databases.Items,
+		brokerConsumers: brokerConsumers.Items,
+		broker:          broker,
+	}, nil
+}
+
+// getModuleSummary reports the health of a module, using the objects it controls
+func getModuleSummary(ctx Context, stack *v1beta1.Stack, module v1beta1.Module, kind, info string, objects *stackObjects) (*v1beta1.ModuleSummary, error) {
+	version, err := getModuleVersion(ctx, stack, module)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &v1beta1.ModuleSummary{
+		Kind:    kind,
+		Name:    module.GetName(),
+		Version: version,
+		Ready:   module.IsReady(),
+	}
+	if !summary.Ready {
+		summary.Error = info
+	}
+
+	for _, deployment := range objects.deployments {
+		if !metav1.IsControlledBy(&deployment, module) {
+			continue
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		summary.Deployments = append(summary.Deployments, v1beta1.DeploymentSummary{
+			Name:              deployment.Name,
+			Replicas:          replicas,
+			AvailableReplicas: deployment.Status.AvailableReplicas,
+		})
+	}
+
+	for _, database := range objects.databases {
+		if !metav1.IsControlledBy(&database, module) {
+			continue
+		}
+		summary.Database = mergeDependencySummary(summary.Database, database.Status.Status)
+	}
+
+	for _, brokerConsumer := range objects.brokerConsumers {
+		if !metav1.IsControlledBy(&brokerConsumer, module) {
+			continue
+		}
+		summary.Broker = mergeDependencySummary(summary.Broker, brokerConsumer.Status.Status)
+	}
+	if _, ok := module.(v1beta1.EventPublisher); ok && objects.broker != nil {
+		summary.Broker = mergeDependencySummary(summary.Broker, objects.broker.Status.Status)
+	}
+
+	return summary, nil
+}
+
+// getModuleVersion returns the version of the module, or an empty string if it can't be resolved
+func getModuleVersion(ctx Context, stack *v1beta1.Stack, module v1beta1.Module) (string, error) {
+	// Custom modules are not released with the stack, their version is defined by their image
+	if _, ok := module.(*v1beta1.CustomModule); ok {
+		return "", nil
+	}
+
+	version, err := ResolveModuleVersion(ctx, stack, module)
+	if err != nil {
+		if errors.Is(err, ErrNoVersionFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return version, nil
+}
+
+// mergeDependencySummary adds the status of an object to the summary of a dependency,
+// the dependency is ready only if all its objects are ready
+func mergeDependencySummary(summary *v1beta1.DependencySummary, status v1beta1.Status) *v1beta1.DependencySummary {
+	if summary == nil {
+		summary = &v1beta1.DependencySummary{
+			Ready: true,
+		}
+	}
+	if !status.Ready {
+		summary.Ready = false
+		if status.Info != "" {
+			summary.Error = strings.TrimPrefix(strings.Join([]string{summary.Error, status.Info}, ", "), ", ")
+		}
+	}
+
+	return summary
+}
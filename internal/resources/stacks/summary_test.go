@@ -0,0 +1,149 @@
+package stacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core/coretest"
+)
+
+func newSummaryStack() *v1beta1.Stack {
+	return &v1beta1.Stack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acme",
+		},
+		Spec: v1beta1.StackSpec{
+			Version: "v2.2.0",
+		},
+	}
+}
+
+func TestGetModuleSummary(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	ctx := coretest.Context{
+		Context: context.Background(),
+		Client:  fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:  scheme,
+	}
+	stack := newSummaryStack()
+	ledger := &v1beta1.Ledger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acme-ledger",
+			UID:  "ledger",
+		},
+		Spec: v1beta1.LedgerSpec{
+			StackDependency: v1beta1.StackDependency{
+				Stack: stack.Name,
+			},
+		},
+	}
+	other := &v1beta1.Payments{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acme-payments",
+			UID:  "payments",
+		},
+	}
+
+	controlledBy := func(owner client.Object, object client.Object) {
+		require.NoError(t, controllerutil.SetControllerReference(owner, object, scheme))
+	}
+
+	ledgerDeployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: stack.Name},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+	}
+	controlledBy(ledger, &ledgerDeployment)
+	paymentsDeployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: stack.Name},
+	}
+	controlledBy(other, &paymentsDeployment)
+
+	database := v1beta1.Database{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme-ledger"},
+		Status: v1beta1.DatabaseStatus{
+			Status: v1beta1.Status{Info: "database creation pending"},
+		},
+	}
+	controlledBy(ledger, &database)
+
+	objects := &stackObjects{
+		deployments: []appsv1.Deployment{ledgerDeployment, paymentsDeployment},
+		databases:   []v1beta1.Database{database},
+		broker: &v1beta1.Broker{
+			Status: v1beta1.BrokerStatus{
+				Status: v1beta1.Status{Ready: true},
+			},
+		},
+	}
+
+	summary, err := getModuleSummary(ctx, stack, ledger, "Ledger", "database not ready", objects)
+	require.NoError(t, err)
+	require.Equal(t, &v1beta1.ModuleSummary{
+		Kind:    "Ledger",
+		Name:    "acme-ledger",
+		Version: "v2.2.0",
+		Error:   "database not ready",
+		Deployments: []v1beta1.DeploymentSummary{{
+			Name:              "ledger",
+			Replicas:          2,
+			AvailableReplicas: 1,
+		}},
+		Database: &v1beta1.DependencySummary{
+			Error: "database creation pending",
+		},
+		// The ledger publishes its events on the broker of the stack
+		Broker: &v1beta1.DependencySummary{
+			Ready: true,
+		},
+	}, summary)
+}
+
+func TestGetModuleSummaryCustomModule(t *testing.T) {
+	t.Parallel()
+
+	// Custom modules are not versioned with the stack
+	customModule := &v1beta1.CustomModule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acme-reporting",
+		},
+		Status: v1beta1.CustomModuleStatus{
+			Status: v1beta1.Status{Ready: true},
+		},
+	}
+	summary, err := getModuleSummary(coretest.NewContext(), newSummaryStack(), customModule, "CustomModule", "", &stackObjects{})
+	require.NoError(t, err)
+	require.Equal(t, &v1beta1.ModuleSummary{
+		Kind:  "CustomModule",
+		Name:  "acme-reporting",
+		Ready: true,
+	}, summary)
+}
+
+func TestMergeDependencySummary(t *testing.T) {
+	t.Parallel()
+
+	summary := mergeDependencySummary(nil, v1beta1.Status{Ready: true})
+	require.Equal(t, &v1beta1.DependencySummary{Ready: true}, summary)
+
+	summary = mergeDependencySummary(summary, v1beta1.Status{Info: "consumer pending"})
+	summary = mergeDependencySummary(summary, v1beta1.Status{Info: "stream pending"})
+	require.Equal(t, &v1beta1.DependencySummary{
+		Error: "consumer pending, stream pending",
+	}, summary)
+}
@@ -1,6 +1,8 @@
 package stargates
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,6 +12,7 @@ import (
 	"github.com/formancehq/operator/v3/internal/resources/applications"
 	"github.com/formancehq/operator/v3/internal/resources/gateways"
 	"github.com/formancehq/operator/v3/internal/resources/registries"
+	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
 	"github.com/formancehq/operator/v3/internal/resources/settings"
 )
 
@@ -36,9 +39,36 @@ func createDeployment(ctx core.Context, stack *v1beta1.Stack, stargate *v1beta1.
 		core.Env("STARGATE_SERVER_URL", stargate.Spec.ServerURL),
 		core.Env("GATEWAY_URL", "http://gateway:8080"),
 		core.Env("STARGATE_AUTH_CLIENT_ID", stargate.Spec.Auth.ClientID),
-		core.Env("STARGATE_AUTH_CLIENT_SECRET", stargate.Spec.Auth.ClientSecret),
 		core.Env("STARGATE_AUTH_ISSUER_URL", stargate.Spec.Auth.Issuer),
 	)
+
+	annotations := map[string]string{}
+	if stargate.Spec.Auth.ClientSecret != "" && stargate.Spec.Auth.ClientSecretFromSecret != nil {
+		return fmt.Errorf("cannot specify client secret using both .spec.auth.clientSecret and .spec.auth.clientSecretFromSecret fields")
+	}
+
+	resourceRefName := "stargate-client-secret"
+	if stargate.Spec.Auth.ClientSecretFromSecret != nil {
+		clientSecretResourceRef, err := resourcereferences.Create(ctx, stargate, resourceRefName, stargate.Spec.Auth.ClientSecretFromSecret.Name, &v1.Secret{})
+		if err != nil {
+			return err
+		}
+
+		annotations[resourceRefName] = clientSecretResourceRef.Status.Hash
+
+		env = append(env, v1.EnvVar{
+			Name: "STARGATE_AUTH_CLIENT_SECRET",
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: stargate.Spec.Auth.ClientSecretFromSecret,
+			},
+		})
+	} else {
+		if err := resourcereferences.Delete(ctx, stargate, resourceRefName); err != nil {
+			return err
+		}
+		env = append(env, core.Env("STARGATE_AUTH_CLIENT_SECRET", stargate.Spec.Auth.ClientSecret))
+	}
+
 	if stargate.Spec.TLS.Disable {
 		env = append(env, core.Env("TLS_ENABLED", "false"))
 	}
@@ -55,6 +85,9 @@ func createDeployment(ctx core.Context, stack *v1beta1.Stack, stargate *v1beta1.
 			},
 			Spec: appsv1.DeploymentSpec{
 				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: annotations,
+					},
 					Spec: v1.PodSpec{
 						ImagePullSecrets: imageConfiguration.PullSecrets,
 						Containers: []v1.Container{{
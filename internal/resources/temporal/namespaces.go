@@ -0,0 +1,343 @@
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	sdklog "go.temporal.io/sdk/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+)
+
+const defaultRetention = 72 * time.Hour
+
+// stackDataKey is the key of the namespace data recording the stack for which the operator registered the namespace.
+// Only the namespaces having it are deleted with their stack.
+const stackDataKey = "formance.com/stack"
+
+// namespaceDeletionTimeout is the duration, from the deletion of the stack, after which the operator gives up
+// deleting the namespace, so an unreachable temporal server does not block the deletion of the stack forever
+const namespaceDeletionTimeout = 10 * time.Minute
+
+// provisionedNamespaces keep track of namespaces already provisioned by the operator to avoid connecting
+// to temporal on each reconciliation
+var provisionedNamespaces sync.Map
+
+// EnsureNamespace creates the namespace targeted by the temporal uri if missing, and registers the search
+// attributes required by the module.
+// It is a no-op unless the setting temporal.namespace.create is true.
+func EnsureNamespace(ctx core.Context, stack *v1beta1.Stack, temporalURI *v1beta1.URI, searchAttributes map[string]enumspb.IndexedValueType) error {
+	create, err := settings.GetBoolOrFalse(ctx, stack.Name, "temporal", "namespace", "create")
+	if err != nil {
+		return err
+	}
+	if !create {
+		return nil
+	}
+
+	retention, err := getRetention(ctx, stack.Name)
+	if err != nil {
+		return err
+	}
+
+	options, err := clientOptions(ctx, stack.Name, temporalURI)
+	if err != nil {
+		return err
+	}
+
+	key := provisioningKey(stack.Name, options.HostPort, options.Namespace, retention, searchAttributes)
+	if _, ok := provisionedNamespaces.Load(key); ok {
+		return nil
+	}
+
+	c, err := client.DialContext(ctx, *options)
+	if err != nil {
+		return core.NewApplicationError().WithMessage("connecting to temporal: %s", err)
+	}
+	defer c.Close()
+
+	if err := ensureNamespace(ctx, c, stack.Name, options.Namespace, retention, searchAttributes); err != nil {
+		return err
+	}
+	provisionedNamespaces.Store(key, struct{}{})
+
+	return nil
+}
+
+// DeleteNamespace deletes the namespace targeted by the temporal.dsn setting of the stack,
+// if it has been registered by the operator for this stack.
+// It is a no-op unless the setting temporal.namespace.delete-on-stack-deletion is true.
+// Errors are returned until namespaceDeletionTimeout is elapsed since the deletion of the stack,
+// then they are logged and the namespace is left in place.
+func DeleteNamespace(ctx core.Context, stack *v1beta1.Stack) error {
+	err := deleteStackNamespace(ctx, stack)
+	if err == nil || !isDeletionTimedOut(stack, time.Now()) {
+		return err
+	}
+
+	log.FromContext(ctx).Error(err, "Unable to delete temporal namespace, giving up",
+		"timeout", namespaceDeletionTimeout)
+	return nil
+}
+
+func isDeletionTimedOut(stack *v1beta1.Stack, now time.Time) bool {
+	return stack.DeletionTimestamp != nil && !now.Before(stack.DeletionTimestamp.Add(namespaceDeletionTimeout))
+}
+
+func deleteStackNamespace(ctx core.Context, stack *v1beta1.Stack) error {
+	enabled, err := settings.GetBoolOrFalse(ctx, stack.Name, "temporal", "namespace", "delete-on-stack-deletion")
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	temporalURI, err := settings.GetURL(ctx, stack.Name, "temporal", "dsn")
+	if err != nil {
+		return err
+	}
+	if temporalURI == nil {
+		return nil
+	}
+
+	options, err := clientOptions(ctx, stack.Name, temporalURI)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.DialContext(ctx, *options)
+	if err != nil {
+		return core.NewApplicationError().WithMessage("connecting to temporal: %s", err)
+	}
+	defer c.Close()
+
+	if err := deleteNamespace(ctx, c, stack.Name, options.Namespace); err != nil {
+		return err
+	}
+
+	provisionedNamespaces.Range(func(key, value any) bool {
+		if strings.HasPrefix(key.(string), stack.Name+"/") {
+			provisionedNamespaces.Delete(key)
+		}
+		return true
+	})
+
+	return nil
+}
+
+func ensureNamespace(ctx context.Context, c client.Client, stack, namespace string, retention time.Duration,
+	searchAttributes map[string]enumspb.IndexedValueType) error {
+
+	logger := log.FromContext(ctx).WithValues("namespace", namespace)
+
+	describeResponse, err := c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	var notFound *serviceerror.NamespaceNotFound
+	switch {
+	case errors.As(err, &notFound):
+		logger.Info("Registering temporal namespace", "retention", retention)
+		if _, err := c.WorkflowService().RegisterNamespace(ctx, &workflowservice.RegisterNamespaceRequest{
+			Namespace:                        namespace,
+			Description:                      "Created by the Formance operator",
+			WorkflowExecutionRetentionPeriod: durationpb.New(retention),
+			Data: map[string]string{
+				stackDataKey: stack,
+			},
+		}); err != nil {
+			var alreadyExists *serviceerror.NamespaceAlreadyExists
+			if !errors.As(err, &alreadyExists) {
+				return fmt.Errorf("registering temporal namespace: %w", err)
+			}
+		}
+	case err != nil:
+		return fmt.Errorf("describing temporal namespace: %w", err)
+	case describeResponse.GetConfig().GetWorkflowExecutionRetentionTtl().AsDuration() != retention:
+		logger.Info("Updating temporal namespace retention", "retention", retention)
+		if _, err := c.WorkflowService().UpdateNamespace(ctx, &workflowservice.UpdateNamespaceRequest{
+			Namespace: namespace,
+			Config: &namespacepb.NamespaceConfig{
+				WorkflowExecutionRetentionTtl: durationpb.New(retention),
+			},
+		}); err != nil {
+			return fmt.Errorf("updating temporal namespace: %w", err)
+		}
+	}
+
+	if len(searchAttributes) == 0 {
+		return nil
+	}
+
+	listResponse, err := c.OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		if errors.As(err, &notFound) {
+			// The namespace has just been created and is not yet available on all temporal nodes
+			return core.NewPendingError().WithMessage("waiting for temporal namespace %s to be available", namespace)
+		}
+		return fmt.Errorf("listing temporal search attributes: %w", err)
+	}
+
+	missingSearchAttributes := map[string]enumspb.IndexedValueType{}
+	for name, indexedValueType := range searchAttributes {
+		if _, ok := listResponse.GetCustomAttributes()[name]; ok {
+			continue
+		}
+		if _, ok := listResponse.GetSystemAttributes()[name]; ok {
+			continue
+		}
+		missingSearchAttributes[name] = indexedValueType
+	}
+	if len(missingSearchAttributes) == 0 {
+		return nil
+	}
+
+	logger.Info("Registering temporal search attributes", "searchAttributes", missingSearchAttributes)
+	if _, err := c.OperatorService().AddSearchAttributes(ctx, &operatorservice.AddSearchAttributesRequest{
+		Namespace:        namespace,
+		SearchAttributes: missingSearchAttributes,
+	}); err != nil {
+		var alreadyExists *serviceerror.AlreadyExists
+		if !errors.As(err, &alreadyExists) {
+			return fmt.Errorf("registering temporal search attributes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteNamespace deletes the namespace only if it has been registered by the operator for the stack,
+// as a namespace created outside the operator, or shared with another stack, could still be in use.
+func deleteNamespace(ctx context.Context, c client.Client, stack, namespace string) error {
+	logger := log.FromContext(ctx).WithValues("namespace", namespace)
+
+	var notFound *serviceerror.NamespaceNotFound
+	describeResponse, err := c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	switch {
+	case errors.As(err, &notFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("describing temporal namespace: %w", err)
+	case describeResponse.GetNamespaceInfo().GetData()[stackDataKey] != stack:
+		logger.Info("Temporal namespace not registered by the operator for the stack, skipping deletion")
+		return nil
+	}
+
+	logger.Info("Deleting temporal namespace")
+	if _, err := c.OperatorService().DeleteNamespace(ctx, &operatorservice.DeleteNamespaceRequest{
+		Namespace: namespace,
+	}); err != nil {
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("deleting temporal namespace: %w", err)
+	}
+	return nil
+}
+
+func getRetention(ctx core.Context, stack string) (time.Duration, error) {
+	value, err := settings.GetString(ctx, stack, "temporal", "namespace", "retention")
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return defaultRetention, nil
+	}
+
+	retention, err := time.ParseDuration(*value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid temporal namespace retention '%s': %w", *value, err)
+	}
+
+	return retention, nil
+}
+
+func clientOptions(ctx core.Context, stack string, temporalURI *v1beta1.URI) (*client.Options, error) {
+	if temporalURI.Scheme != "temporal" || len(temporalURI.Path) < 2 {
+		return nil, fmt.Errorf("invalid temporal uri: %s", temporalURI.String())
+	}
+
+	secretName := temporalURI.Query().Get("secret")
+	if secretName == "" {
+		var err error
+		secretName, err = settings.GetStringOrEmpty(ctx, stack, "temporal", "tls", "secret")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var certPEM, keyPEM []byte
+	if secretName != "" {
+		secret, err := resourcereferences.FindSecret(ctx, stack, secretName)
+		if err != nil {
+			return nil, err
+		}
+		certPEM, keyPEM = secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	} else {
+		crt, err := settings.GetStringOrEmpty(ctx, stack, "temporal", "tls", "crt")
+		if err != nil {
+			return nil, err
+		}
+		key, err := settings.GetStringOrEmpty(ctx, stack, "temporal", "tls", "key")
+		if err != nil {
+			return nil, err
+		}
+		certPEM, keyPEM = []byte(crt), []byte(key)
+	}
+
+	options := &client.Options{
+		HostPort:  temporalURI.Host,
+		Namespace: temporalURI.Path[1:],
+		Logger:    sdklog.NewStructuredLogger(slog.New(logr.ToSlogHandler(log.FromContext(ctx)))),
+	}
+	if len(certPEM) > 0 && len(keyPEM) > 0 {
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading temporal client certificate: %w", err)
+		}
+		options.ConnectionOptions.TLS = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+		}
+	}
+
+	return options, nil
+}
+
+func provisioningKey(stack, hostPort, namespace string, retention time.Duration, searchAttributes map[string]enumspb.IndexedValueType) string {
+	names := make([]string, 0, len(searchAttributes))
+	for name := range searchAttributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	key := fmt.Sprintf("%s/%s/%s/%s", stack, hostPort, namespace, retention)
+	for _, name := range names {
+		key += fmt.Sprintf("/%s=%s", name, searchAttributes[name])
+	}
+
+	return key
+}
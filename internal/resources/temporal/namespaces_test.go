@@ -0,0 +1,136 @@
+package temporal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/testsuite"
+	"google.golang.org/protobuf/types/known/durationpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func startDevServer(t *testing.T) client.Client {
+	t.Helper()
+
+	server, err := testsuite.StartDevServer(context.Background(), testsuite.DevServerOptions{
+		LogLevel: "error",
+	})
+	if err != nil {
+		t.Skipf("unable to start temporal dev server: %s", err)
+	}
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop())
+	})
+
+	return server.Client()
+}
+
+func TestNamespaceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	c := startDevServer(t)
+	ctx := context.Background()
+	searchAttributes := map[string]enumspb.IndexedValueType{
+		"stack": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	}
+
+	// Create the namespace, search attributes can be registered only when the namespace is available
+	require.Eventually(t, func() bool {
+		return ensureNamespace(ctx, c, "stack0", "formance-test", 48*time.Hour, searchAttributes) == nil
+	}, 30*time.Second, 500*time.Millisecond)
+
+	describeResponse, err := c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: "formance-test",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 48*time.Hour, describeResponse.GetConfig().GetWorkflowExecutionRetentionTtl().AsDuration())
+
+	listResponse, err := c.OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{
+		Namespace: "formance-test",
+	})
+	require.NoError(t, err)
+	require.Equal(t, enumspb.INDEXED_VALUE_TYPE_KEYWORD, listResponse.GetCustomAttributes()["stack"])
+
+	// Provisioning is idempotent and updates the retention
+	require.NoError(t, ensureNamespace(ctx, c, "stack0", "formance-test", 24*time.Hour, searchAttributes))
+	describeResponse, err = c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: "formance-test",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, describeResponse.GetConfig().GetWorkflowExecutionRetentionTtl().AsDuration())
+
+	// The namespace is not deleted for another stack
+	require.NoError(t, deleteNamespace(ctx, c, "stack1", "formance-test"))
+	_, err = c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: "formance-test",
+	})
+	require.NoError(t, err)
+
+	// Delete the namespace, deleting an already deleted namespace is not an error
+	require.NoError(t, deleteNamespace(ctx, c, "stack0", "formance-test"))
+	require.NoError(t, deleteNamespace(ctx, c, "stack0", "formance-test"))
+}
+
+func TestDeleteNamespaceNotRegisteredByOperator(t *testing.T) {
+	t.Parallel()
+
+	c := startDevServer(t)
+	ctx := context.Background()
+
+	_, err := c.WorkflowService().RegisterNamespace(ctx, &workflowservice.RegisterNamespaceRequest{
+		Namespace:                        "external",
+		WorkflowExecutionRetentionPeriod: durationpb.New(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// The namespace exists, so provisioning it does not mark it
+	require.Eventually(t, func() bool {
+		return ensureNamespace(ctx, c, "stack0", "external", 24*time.Hour, nil) == nil
+	}, 30*time.Second, 500*time.Millisecond)
+
+	require.NoError(t, deleteNamespace(ctx, c, "stack0", "external"))
+	_, err = c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: "external",
+	})
+	require.NoError(t, err)
+}
+
+func TestIsDeletionTimedOut(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stack := &v1beta1.Stack{}
+	require.False(t, isDeletionTimedOut(stack, now))
+
+	stack.DeletionTimestamp = &metav1.Time{Time: now}
+	require.False(t, isDeletionTimedOut(stack, now.Add(namespaceDeletionTimeout-time.Second)))
+	require.True(t, isDeletionTimedOut(stack, now.Add(namespaceDeletionTimeout)))
+}
+
+func TestProvisioningKey(t *testing.T) {
+	t.Parallel()
+
+	key1 := provisioningKey("stack0", "localhost:7233", "ns", time.Hour, map[string]enumspb.IndexedValueType{
+		"a": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		"b": enumspb.INDEXED_VALUE_TYPE_INT,
+	})
+	key2 := provisioningKey("stack0", "localhost:7233", "ns", time.Hour, map[string]enumspb.IndexedValueType{
+		"b": enumspb.INDEXED_VALUE_TYPE_INT,
+		"a": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	})
+	require.Equal(t, key1, key2)
+
+	key3 := provisioningKey("stack0", "localhost:7233", "ns", 2*time.Hour, map[string]enumspb.IndexedValueType{
+		"a": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		"b": enumspb.INDEXED_VALUE_TYPE_INT,
+	})
+	require.NotEqual(t, key1, key3)
+}
@@ -0,0 +1,82 @@
+package temporal
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+)
+
+const tlsResourceReference = "temporal-tls"
+
+// TLS contains the configuration to apply on a module container to connect to temporal using mTLS
+type TLS struct {
+	Env []corev1.EnvVar
+	// Hash is the hash of the referenced secret, it must be added to the pod annotations to trigger a rollout on rotation
+	Hash string
+}
+
+// GetTLS resolves the temporal client certificate of a module.
+// By order of precedence, the certificate is loaded from:
+//   - the secret defined by the query parameter "secret" of the temporal.dsn setting, as env vars
+//   - the kubernetes.io/tls secret defined by the temporal.tls.secret setting, copied in the stack namespace, as env vars
+//   - the deprecated temporal.tls.crt and temporal.tls.key settings, as plain env vars
+func GetTLS(ctx core.Context, stack *v1beta1.Stack, owner v1beta1.Dependent, temporalURI *v1beta1.URI) (*TLS, error) {
+	ret := &TLS{}
+
+	secretName, err := settings.GetStringOrEmpty(ctx, stack.Name, "temporal", "tls", "secret")
+	if err != nil {
+		return nil, err
+	}
+
+	if secret := temporalURI.Query().Get("secret"); secret == "" && secretName != "" {
+		ref, err := resourcereferences.Create(ctx, owner, tlsResourceReference, secretName, &corev1.Secret{})
+		if err != nil {
+			return nil, err
+		}
+
+		ret.Hash = ref.Status.Hash
+		ret.Env = []corev1.EnvVar{
+			core.EnvFromSecret("TEMPORAL_SSL_CLIENT_KEY", secretName, corev1.TLSPrivateKeyKey),
+			core.EnvFromSecret("TEMPORAL_SSL_CLIENT_CERT", secretName, corev1.TLSCertKey),
+		}
+
+		return ret, nil
+	}
+
+	if err := resourcereferences.Delete(ctx, owner, tlsResourceReference); err != nil {
+		return nil, err
+	}
+
+	if secret := temporalURI.Query().Get("secret"); secret != "" {
+		ret.Env = []corev1.EnvVar{
+			core.EnvFromSecret("TEMPORAL_SSL_CLIENT_KEY", secret, corev1.TLSPrivateKeyKey),
+			core.EnvFromSecret("TEMPORAL_SSL_CLIENT_CERT", secret, corev1.TLSCertKey),
+		}
+		return ret, nil
+	}
+
+	crt, err := settings.GetStringOrEmpty(ctx, stack.Name, "temporal", "tls", "crt")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := settings.GetStringOrEmpty(ctx, stack.Name, "temporal", "tls", "key")
+	if err != nil {
+		return nil, err
+	}
+
+	if crt != "" || key != "" {
+		log.FromContext(ctx).Info("Settings temporal.tls.crt and temporal.tls.key are deprecated, use temporal.tls.secret instead")
+	}
+
+	ret.Env = []corev1.EnvVar{
+		core.Env("TEMPORAL_SSL_CLIENT_KEY", key),
+		core.Env("TEMPORAL_SSL_CLIENT_CERT", crt),
+	}
+
+	return ret, nil
+}
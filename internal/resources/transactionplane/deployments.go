@@ -116,6 +116,10 @@ func createDeployments(
 	if err != nil {
 		return err
 	}
+	annotations, err := authclients.GetAnnotations(ctx, client)
+	if err != nil {
+		return err
+	}
 
 	serviceAccountName, err := settings.GetAWSServiceAccount(ctx, stack.Name)
 	if err != nil {
@@ -131,10 +135,10 @@ func createDeployments(
 		if err := deleteDeployment(ctx, stack, "transactionplane-worker"); err != nil {
 			return err
 		}
-		return createSingleDeployment(ctx, t, imageConfiguration, serviceAccountName, env)
+		return createSingleDeployment(ctx, t, imageConfiguration, serviceAccountName, env, annotations)
 	}
 
-	return createSeparateDeployments(ctx, t, imageConfiguration, serviceAccountName, env)
+	return createSeparateDeployments(ctx, t, imageConfiguration, serviceAccountName, env, annotations)
 }
 
 func deleteDeployment(ctx Context, stack *v1beta1.Stack, name string) error {
@@ -164,6 +168,7 @@ func createSingleDeployment(
 	imageConfiguration *registries.ImageConfiguration,
 	serviceAccountName string,
 	env []corev1.EnvVar,
+	annotations map[string]string,
 ) error {
 	env = append(env, Env("WORKER_ENABLED", "true"))
 
@@ -173,6 +178,9 @@ func createSingleDeployment(
 		},
 		Spec: appsv1.DeploymentSpec{
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+				},
 				Spec: corev1.PodSpec{
 					ImagePullSecrets:   imageConfiguration.PullSecrets,
 					ServiceAccountName: serviceAccountName,
@@ -201,6 +209,7 @@ func createSeparateDeployments(
 	imageConfiguration *registries.ImageConfiguration,
 	serviceAccountName string,
 	env []corev1.EnvVar,
+	annotations map[string]string,
 ) error {
 	// Worker deployment first (same pattern as payments: deploy worker before API)
 	workerTpl := &appsv1.Deployment{
@@ -209,6 +218,9 @@ func createSeparateDeployments(
 		},
 		Spec: appsv1.DeploymentSpec{
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+				},
 				Spec: corev1.PodSpec{
 					ImagePullSecrets:   imageConfiguration.PullSecrets,
 					ServiceAccountName: serviceAccountName,
@@ -237,6 +249,9 @@ func createSeparateDeployments(
 		},
 		Spec: appsv1.DeploymentSpec{
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+				},
 				Spec: corev1.PodSpec{
 					ImagePullSecrets:   imageConfiguration.PullSecrets,
 					ServiceAccountName: serviceAccountName,
@@ -41,7 +41,7 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, t *v1beta1.TransactionPlane, v
 	}
 
 	if !database.Status.Ready {
-		return NewPendingError().WithMessage("database not ready")
+		return NewPendingError().WithMessage("database not ready").OnlyOnWatchEvent()
 	}
 
 	imageConfiguration, err := registries.GetFormanceImage(ctx, stack, "transaction-plane", version)
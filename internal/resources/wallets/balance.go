@@ -0,0 +1,57 @@
+package wallets
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+)
+
+func ReconcileBalance(ctx core.Context, stack *v1beta1.Stack, b *v1beta1.WalletBalance) error {
+	w, err := getWallet(ctx, stack.Name, b.Spec.Wallet)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newAPIClient(ctx, stack, b)
+	if err != nil {
+		return err
+	}
+
+	return reconcileBalance(ctx, apiClient, b, w.Status.WalletID)
+}
+
+func reconcileBalance(ctx core.Context, apiClient *apiclients.Client, b *v1beta1.WalletBalance, walletID string) error {
+	path := fmt.Sprintf("%s/balances/%s", walletPath(walletID), url.PathEscape(b.GetBalanceName()))
+	err := apiClient.Do(ctx, http.MethodGet, path, nil, nil)
+	switch {
+	case err == nil:
+		b.Status.WalletID = walletID
+		return nil
+	case !apiclients.IsNotFound(err):
+		return core.NewApplicationError().WithMessage("getting balance: %s", err)
+	}
+
+	body := map[string]any{
+		"name": b.GetBalanceName(),
+	}
+	if b.Spec.ExpiresAt != nil {
+		body["expiresAt"] = b.Spec.ExpiresAt.UTC()
+	}
+	if b.Spec.Priority != 0 {
+		body["priority"] = b.Spec.Priority
+	}
+
+	log.FromContext(ctx).Info("Creating wallet balance", "wallet", walletID, "name", b.GetBalanceName())
+	if err := apiClient.Do(ctx, http.MethodPost, walletPath(walletID)+"/balances", body, nil); err != nil {
+		return core.NewApplicationError().WithMessage("creating balance: %s", err)
+	}
+	b.Status.WalletID = walletID
+
+	return nil
+}
@@ -46,6 +46,11 @@ func createDeployment(ctx core.Context, stack *v1beta1.Stack, wallets *v1beta1.W
 		return err
 	}
 
+	annotations, err := authclients.GetAnnotations(ctx, authClient)
+	if err != nil {
+		return err
+	}
+
 	return applications.
 		New(wallets, &appsv1.Deployment{
 			ObjectMeta: metav1.ObjectMeta{
@@ -53,6 +58,9 @@ func createDeployment(ctx core.Context, stack *v1beta1.Stack, wallets *v1beta1.W
 			},
 			Spec: appsv1.DeploymentSpec{
 				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: annotations,
+					},
 					Spec: v1.PodSpec{
 						ImagePullSecrets: imageConfiguration.PullSecrets,
 						Containers: []v1.Container{{
@@ -28,6 +28,12 @@ import (
 //+kubebuilder:rbac:groups=formance.com,resources=wallets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=formance.com,resources=wallets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=formance.com,resources=wallets/finalizers,verbs=update
+//+kubebuilder:rbac:groups=formance.com,resources=stackwallets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=stackwallets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=stackwallets/finalizers,verbs=update
+//+kubebuilder:rbac:groups=formance.com,resources=walletbalances,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=walletbalances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=walletbalances/finalizers,verbs=update
 
 func Reconcile(ctx Context, stack *v1beta1.Stack, wallets *v1beta1.Wallets, version string) error {
 
@@ -66,5 +72,14 @@ func init() {
 			WithOwn[*v1beta1.Wallets](&v1beta1.GatewayHTTPAPI{}),
 			WithOwn[*v1beta1.Wallets](&v1beta1.ResourceReference{}),
 		),
+		WithStackDependencyReconciler(ReconcileWallet,
+			WithWatchDependency[*v1beta1.Wallet](&v1beta1.Wallets{}),
+			WithWatchDependency[*v1beta1.Wallet](&v1beta1.AuthClient{}),
+		),
+		WithStackDependencyReconciler(ReconcileBalance,
+			WithWatchDependency[*v1beta1.WalletBalance](&v1beta1.Wallets{}),
+			WithWatchDependency[*v1beta1.WalletBalance](&v1beta1.AuthClient{}),
+			WithWatchDependency[*v1beta1.WalletBalance](&v1beta1.Wallet{}),
+		),
 	)
 }
@@ -0,0 +1,140 @@
+package wallets
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+)
+
+// walletMetadataKey is the metadata used to retrieve the wallet created for a Wallet resource
+const walletMetadataKey = "formance.com/wallet"
+
+type wallet struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+func ReconcileWallet(ctx core.Context, stack *v1beta1.Stack, w *v1beta1.Wallet) error {
+	apiClient, err := newAPIClient(ctx, stack, w)
+	if err != nil {
+		return err
+	}
+
+	return reconcileWallet(ctx, apiClient, w)
+}
+
+func reconcileWallet(ctx core.Context, apiClient *apiclients.Client, w *v1beta1.Wallet) error {
+	metadata := map[string]string{}
+	maps.Copy(metadata, w.Spec.Metadata)
+	metadata[walletMetadataKey] = w.Name
+
+	var existing *wallet
+	if w.Status.WalletID != "" {
+		ret := apiclients.Data[wallet]{}
+		err := apiClient.Do(ctx, http.MethodGet, walletPath(w.Status.WalletID), nil, &ret)
+		switch {
+		case err == nil:
+			existing = &ret.Data
+		case !apiclients.IsNotFound(err):
+			return core.NewApplicationError().WithMessage("getting wallet: %s", err)
+		}
+	}
+	if existing == nil {
+		query := url.Values{}
+		query.Set(fmt.Sprintf("metadata[%s]", walletMetadataKey), w.Name)
+		ret := apiclients.Cursor[wallet]{}
+		if err := apiClient.Do(ctx, http.MethodGet, "/wallets?"+query.Encode(), nil, &ret); err != nil {
+			return core.NewApplicationError().WithMessage("listing wallets: %s", err)
+		}
+		if len(ret.Cursor.Data) > 0 {
+			existing = &ret.Cursor.Data[0]
+		}
+	}
+
+	if existing == nil {
+		log.FromContext(ctx).Info("Creating wallet", "name", w.GetWalletName())
+		ret := apiclients.Data[wallet]{}
+		if err := apiClient.Do(ctx, http.MethodPost, "/wallets", map[string]any{
+			"name":     w.GetWalletName(),
+			"metadata": metadata,
+		}, &ret); err != nil {
+			return core.NewApplicationError().WithMessage("creating wallet: %s", err)
+		}
+		w.Status.WalletID = ret.Data.ID
+		return nil
+	}
+
+	w.Status.WalletID = existing.ID
+	if existing.Name != w.GetWalletName() {
+		return core.NewApplicationError().WithMessage("wallet %s already exists with name '%s', the name of a wallet cannot be changed",
+			existing.ID, existing.Name)
+	}
+
+	if !containsMetadata(existing.Metadata, metadata) {
+		log.FromContext(ctx).Info("Updating wallet metadata", "id", existing.ID)
+		if err := apiClient.Do(ctx, http.MethodPatch, walletPath(existing.ID), map[string]any{
+			"metadata": metadata,
+		}, nil); err != nil {
+			return core.NewApplicationError().WithMessage("updating wallet metadata: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// containsMetadata checks if all the metadata of the resource are set on the wallet,
+// metadata added on the wallet by other means are kept
+func containsMetadata(actual, expected map[string]string) bool {
+	for key, value := range expected {
+		if v, ok := actual[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func walletPath(id string) string {
+	return fmt.Sprintf("/wallets/%s", url.PathEscape(id))
+}
+
+// newAPIClient creates a client for the wallets API of the stack of the object.
+// It also ensures the object is owned by the stack, so it is removed with it.
+func newAPIClient(ctx core.Context, stack *v1beta1.Stack, object v1beta1.Dependent) (*apiclients.Client, error) {
+	wallets := &v1beta1.Wallets{}
+	if err := core.GetModuleOfDependent(ctx, stack, object, wallets); err != nil {
+		return nil, err
+	}
+
+	return apiclients.New(ctx, stack, wallets, "wallets:read", "wallets:write")
+}
+
+// getWallet returns the Wallet resource referenced by a dependent object of the same stack
+func getWallet(ctx core.Context, stack, name string) (*v1beta1.Wallet, error) {
+	w := &v1beta1.Wallet{}
+	if err := ctx.GetClient().Get(ctx, types.NamespacedName{
+		Name: name,
+	}, w); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, core.NewPendingError().WithMessage("wallet %s not found", name)
+		}
+		return nil, err
+	}
+	if w.Spec.Stack != stack {
+		return nil, core.NewApplicationError().WithMessage("wallet %s is not part of stack %s", name, stack)
+	}
+	if !w.Status.Ready || w.Status.WalletID == "" {
+		return nil, core.NewPendingError().WithMessage("waiting for wallet %s to be ready", name)
+	}
+
+	return w, nil
+}
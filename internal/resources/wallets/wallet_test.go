@@ -0,0 +1,166 @@
+package wallets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core/coretest"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients/apiclientstest"
+)
+
+type fakeWalletsAPI struct {
+	wallets  map[string]*wallet
+	balances map[string]map[string]any
+}
+
+func (f *fakeWalletsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := apiclientstest.PathSegments(r)
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/wallets":
+		data := make([]*wallet, 0)
+		for _, wallet := range f.wallets {
+			if value := r.URL.Query().Get("metadata[" + walletMetadataKey + "]"); value == wallet.Metadata[walletMetadataKey] {
+				data = append(data, wallet)
+			}
+		}
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": data}})
+	case r.Method == http.MethodPost && r.URL.Path == "/wallets":
+		wallet := &wallet{}
+		_ = json.NewDecoder(r.Body).Decode(wallet)
+		wallet.ID = fmt.Sprintf("wallet-%d", len(f.wallets)+1)
+		f.wallets[wallet.ID] = wallet
+		apiclientstest.WriteJSON(w, http.StatusCreated, map[string]any{"data": wallet})
+	case len(path) >= 2 && path[0] == "wallets" && f.wallets[path[1]] != nil:
+		wallet := f.wallets[path[1]]
+		switch {
+		case len(path) == 2 && r.Method == http.MethodGet:
+			apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"data": wallet})
+		case len(path) == 2 && r.Method == http.MethodPatch:
+			body := map[string]map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			for key, value := range body["metadata"] {
+				wallet.Metadata[key] = value
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case len(path) == 3 && r.Method == http.MethodPost:
+			body := map[string]any{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.balances[wallet.ID+"/"+body["name"].(string)] = body
+			apiclientstest.WriteJSON(w, http.StatusCreated, map[string]any{"data": body})
+		case len(path) == 4 && r.Method == http.MethodGet:
+			balance, ok := f.balances[wallet.ID+"/"+path[3]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"data": balance})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// newWalletsTest returns the fake API, a client targeting it, and a wallet already created on it
+func newWalletsTest(t *testing.T) (*fakeWalletsAPI, *apiclients.Client, *v1beta1.Wallet) {
+	t.Helper()
+
+	api := &fakeWalletsAPI{
+		wallets:  map[string]*wallet{},
+		balances: map[string]map[string]any{},
+	}
+	apiClient := apiclientstest.NewClient(t, api)
+
+	w := &v1beta1.Wallet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fees",
+		},
+		Spec: v1beta1.WalletSpec{
+			Metadata: map[string]string{"kind": "system"},
+		},
+	}
+	require.NoError(t, reconcileWallet(coretest.NewContext(), apiClient, w))
+
+	return api, apiClient, w
+}
+
+func TestReconcileWalletCreate(t *testing.T) {
+	t.Parallel()
+
+	api, _, w := newWalletsTest(t)
+
+	require.NotEmpty(t, w.Status.WalletID)
+	require.Equal(t, "fees", api.wallets[w.Status.WalletID].Name)
+	require.Equal(t, "fees", api.wallets[w.Status.WalletID].Metadata[walletMetadataKey])
+}
+
+func TestReconcileWalletStatusLost(t *testing.T) {
+	t.Parallel()
+
+	api, apiClient, w := newWalletsTest(t)
+	walletID := w.Status.WalletID
+
+	// The wallet is found using its metadata, a new one is not created
+	w.Status = v1beta1.WalletStatus{}
+	require.NoError(t, reconcileWallet(coretest.NewContext(), apiClient, w))
+	require.Equal(t, walletID, w.Status.WalletID)
+	require.Len(t, api.wallets, 1)
+}
+
+func TestReconcileWalletMetadata(t *testing.T) {
+	t.Parallel()
+
+	api, apiClient, w := newWalletsTest(t)
+	walletID := w.Status.WalletID
+
+	// The metadata added externally are kept
+	api.wallets[walletID].Metadata["external"] = "true"
+	w.Spec.Metadata["kind"] = "treasury"
+	require.NoError(t, reconcileWallet(coretest.NewContext(), apiClient, w))
+	require.Equal(t, "treasury", api.wallets[walletID].Metadata["kind"])
+	require.Equal(t, "true", api.wallets[walletID].Metadata["external"])
+}
+
+func TestReconcileWalletName(t *testing.T) {
+	t.Parallel()
+
+	_, apiClient, w := newWalletsTest(t)
+
+	// The name cannot be changed
+	w.Spec.Name = "other"
+	require.Error(t, reconcileWallet(coretest.NewContext(), apiClient, w))
+}
+
+func TestReconcileBalance(t *testing.T) {
+	t.Parallel()
+
+	api, apiClient, w := newWalletsTest(t)
+	walletID := w.Status.WalletID
+
+	b := &v1beta1.WalletBalance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "main",
+		},
+		Spec: v1beta1.WalletBalanceSpec{
+			Wallet:   w.Name,
+			Priority: 10,
+		},
+	}
+	require.NoError(t, reconcileBalance(coretest.NewContext(), apiClient, b, walletID))
+	require.Equal(t, walletID, b.Status.WalletID)
+	require.Contains(t, api.balances, walletID+"/main")
+	require.EqualValues(t, 10, api.balances[walletID+"/main"]["priority"])
+
+	// Balances are created once
+	require.NoError(t, reconcileBalance(coretest.NewContext(), apiClient, b, walletID))
+	require.Len(t, api.balances, 1)
+}
@@ -0,0 +1,308 @@
+package webhooks
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+	"github.com/formancehq/operator/v3/internal/resources/resourcereferences"
+)
+
+const (
+	endpointsRefreshInterval = time.Minute
+
+	attemptStatusSuccess = "success"
+)
+
+type webhookConfig struct {
+	ID         string   `json:"id"`
+	Endpoint   string   `json:"endpoint"`
+	EventTypes []string `json:"eventTypes"`
+	Active     bool     `json:"active"`
+}
+
+type webhookConfigInput struct {
+	Endpoint   string   `json:"endpoint"`
+	EventTypes []string `json:"eventTypes"`
+	Secret     string   `json:"secret,omitempty"`
+}
+
+type attempt struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Status    string    `json:"status"`
+}
+
+func ReconcileEndpoint(ctx core.Context, stack *v1beta1.Stack, endpoint *v1beta1.WebhookEndpoint) error {
+	webhooks := &v1beta1.Webhooks{}
+	if err := core.GetModuleOfDependent(ctx, stack, endpoint, webhooks); err != nil {
+		return err
+	}
+
+	input, err := endpointConfig(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	configHash, err := hashEndpointConfig(input)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := apiclients.New(ctx, stack, webhooks, "webhooks:read", "webhooks:write")
+	if err != nil {
+		return err
+	}
+
+	return reconcileEndpoint(ctx, apiClient, endpoint, input, configHash)
+}
+
+func reconcileEndpoint(ctx core.Context, apiClient *apiclients.Client, endpoint *v1beta1.WebhookEndpoint,
+	input webhookConfigInput, configHash string) error {
+
+	if err := deletePendingConfigs(ctx, apiClient, endpoint); err != nil {
+		return err
+	}
+
+	var current *webhookConfig
+	if endpoint.Status.ConfigID == "" {
+		existing, err := findConfig(ctx, apiClient, url.Values{"endpoint": {input.Endpoint}}, func(config webhookConfig) bool {
+			return sameEventTypes(config.EventTypes, input.EventTypes)
+		})
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			// The secret can't be read from the API, so we cannot know if it matches, in doubt, it is updated
+			current = existing
+			endpoint.Status.ConfigID = existing.ID
+			endpoint.Status.ConfigHash = ""
+		}
+	} else {
+		existing, err := findConfig(ctx, apiClient, url.Values{"id": {endpoint.Status.ConfigID}}, nil)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			// The config has been removed externally, it will be created again
+			endpoint.Status.ConfigID = ""
+			endpoint.Status.ConfigHash = ""
+		}
+		current = existing
+	}
+
+	switch {
+	case current == nil:
+		log.FromContext(ctx).Info("Creating webhook config", "endpoint", input.Endpoint)
+		ret := apiclients.Data[webhookConfig]{}
+		if err := apiClient.Do(ctx, http.MethodPost, "/configs", input, &ret); err != nil {
+			return core.NewApplicationError().WithMessage("creating webhook config: %s", err)
+		}
+		current = &ret.Data
+		endpoint.Status.ConfigID = ret.Data.ID
+		endpoint.Status.ConfigHash = configHash
+	case endpoint.Status.ConfigHash != configHash:
+		if current.Endpoint != input.Endpoint || !sameEventTypes(current.EventTypes, input.EventTypes) {
+			// The endpoint and the event types of a config cannot be updated, the config is replaced
+			log.FromContext(ctx).Info("Replacing webhook config", "id", current.ID)
+			ret := apiclients.Data[webhookConfig]{}
+			if err := apiClient.Do(ctx, http.MethodPost, "/configs", input, &ret); err != nil {
+				return core.NewApplicationError().WithMessage("creating webhook config: %s", err)
+			}
+			// The previous config is kept in the status until its deletion succeeds
+			endpoint.Status.PendingDeletion = append(endpoint.Status.PendingDeletion, current.ID)
+			current = &ret.Data
+			endpoint.Status.ConfigID = ret.Data.ID
+			endpoint.Status.ConfigHash = configHash
+			if err := deletePendingConfigs(ctx, apiClient, endpoint); err != nil {
+				return err
+			}
+		} else if input.Secret != "" {
+			log.FromContext(ctx).Info("Updating webhook config secret", "id", current.ID)
+			if err := apiClient.Do(ctx, http.MethodPut, configPath(current.ID)+"/secret/change", map[string]any{
+				"secret": input.Secret,
+			}, nil); err != nil {
+				return core.NewApplicationError().WithMessage("changing webhook config secret: %s", err)
+			}
+		}
+		endpoint.Status.ConfigHash = configHash
+	}
+
+	if current.Active == endpoint.Spec.Paused {
+		action := "activate"
+		if endpoint.Spec.Paused {
+			action = "deactivate"
+		}
+		log.FromContext(ctx).Info("Updating webhook config state", "id", current.ID, "action", action)
+		if err := apiClient.Do(ctx, http.MethodPut, configPath(current.ID)+"/"+action, nil, nil); err != nil {
+			return core.NewApplicationError().WithMessage("updating webhook config state: %s", err)
+		}
+	}
+
+	return updateDeliveryStatus(ctx, apiClient, endpoint)
+}
+
+// deletePendingConfigs deletes the replaced configs, they are removed from the status once deleted
+func deletePendingConfigs(ctx core.Context, apiClient *apiclients.Client, endpoint *v1beta1.WebhookEndpoint) error {
+	for len(endpoint.Status.PendingDeletion) > 0 {
+		id := endpoint.Status.PendingDeletion[0]
+		log.FromContext(ctx).Info("Deleting previous webhook config", "id", id)
+		if err := apiClient.Do(ctx, http.MethodDelete, configPath(id), nil, nil); err != nil &&
+			!apiclients.IsNotFound(err) {
+			return core.NewApplicationError().WithMessage("deleting previous webhook config %s: %s", id, err)
+		}
+		endpoint.Status.PendingDeletion = endpoint.Status.PendingDeletion[1:]
+	}
+	endpoint.Status.PendingDeletion = nil
+
+	return nil
+}
+
+// updateDeliveryStatus reports the last delivery attempts in the status of the endpoint.
+// Attempts are only exposed by recent versions of the webhooks module, the status is left untouched otherwise.
+func updateDeliveryStatus(ctx core.Context, apiClient *apiclients.Client, endpoint *v1beta1.WebhookEndpoint) error {
+	query := url.Values{}
+	query.Set("configID", endpoint.Status.ConfigID)
+	query.Set("pageSize", "100")
+
+	attempts := apiclients.Cursor[attempt]{}
+	if err := apiClient.Do(ctx, http.MethodGet, "/attempts?"+query.Encode(), nil, &attempts); err != nil {
+		if apiclients.IsNotFound(err) {
+			return nil
+		}
+		return core.NewApplicationError().WithMessage("listing webhook attempts: %s", err)
+	}
+
+	slices.SortFunc(attempts.Cursor.Data, func(a, b attempt) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	endpoint.Status.FailureCount = 0
+	for _, attempt := range attempts.Cursor.Data {
+		if attempt.Status == attemptStatusSuccess {
+			break
+		}
+		endpoint.Status.FailureCount++
+	}
+	if len(attempts.Cursor.Data) > 0 {
+		endpoint.Status.LastAttempt = &metav1.Time{Time: attempts.Cursor.Data[0].CreatedAt}
+		endpoint.Status.LastAttemptStatus = attempts.Cursor.Data[0].Status
+	}
+
+	return nil
+}
+
+func findConfig(ctx core.Context, apiClient *apiclients.Client, query url.Values, match func(webhookConfig) bool) (*webhookConfig, error) {
+	for {
+		ret := apiclients.Cursor[webhookConfig]{}
+		if err := apiClient.Do(ctx, http.MethodGet, "/configs?"+query.Encode(), nil, &ret); err != nil {
+			return nil, core.NewApplicationError().WithMessage("listing webhook configs: %s", err)
+		}
+		for _, config := range ret.Cursor.Data {
+			if match == nil || match(config) {
+				return &config, nil
+			}
+		}
+		if !ret.Cursor.HasMore {
+			return nil, nil
+		}
+		query = url.Values{}
+		query.Set("cursor", ret.Cursor.Next)
+	}
+}
+
+func endpointConfig(ctx core.Context, endpoint *v1beta1.WebhookEndpoint) (webhookConfigInput, error) {
+	input := webhookConfigInput{
+		Endpoint:   endpoint.Spec.URL,
+		EventTypes: endpoint.Spec.EventTypes,
+	}
+	if input.EventTypes == nil {
+		input.EventTypes = []string{}
+	}
+
+	if endpoint.Spec.Secret == nil {
+		if err := resourcereferences.Delete(ctx, endpoint, "secret"); err != nil {
+			return input, err
+		}
+		return input, nil
+	}
+
+	secret, err := resourcereferences.GetSecret(ctx, endpoint, "secret", endpoint.Spec.Secret.Name)
+	if err != nil {
+		return input, err
+	}
+
+	value, ok := secret.Data[endpoint.Spec.Secret.Key]
+	if !ok {
+		return input, core.NewApplicationError().WithMessage("key '%s' not found in secret '%s'",
+			endpoint.Spec.Secret.Key, endpoint.Spec.Secret.Name)
+	}
+	input.Secret = string(value)
+
+	return input, nil
+}
+
+func sameEventTypes(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func configPath(id string) string {
+	return fmt.Sprintf("/configs/%s", url.PathEscape(id))
+}
+
+func hashEndpointConfig(input webhookConfigInput) (string, error) {
+	input.EventTypes = slices.Clone(input.EventTypes)
+	slices.Sort(input.EventTypes)
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(data)
+
+	return base64.StdEncoding.EncodeToString(digest[:]), nil
+}
+
+func CleanEndpoint(ctx core.Context, endpoint *v1beta1.WebhookEndpoint) error {
+	if endpoint.Status.ConfigID == "" && len(endpoint.Status.PendingDeletion) == 0 {
+		return nil
+	}
+
+	webhooks := &v1beta1.Webhooks{}
+	stack, available, err := core.GetModuleForCleanup(ctx, endpoint.Spec.Stack, webhooks)
+	if err != nil || !available {
+		return err
+	}
+
+	apiClient, err := apiclients.New(ctx, stack, webhooks, "webhooks:read", "webhooks:write")
+	if err != nil {
+		return err
+	}
+
+	if err := deletePendingConfigs(ctx, apiClient, endpoint); err != nil {
+		return err
+	}
+	if endpoint.Status.ConfigID == "" {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Deleting webhook config", "id", endpoint.Status.ConfigID)
+	if err := apiClient.Do(ctx, http.MethodDelete, configPath(endpoint.Status.ConfigID), nil, nil); err != nil &&
+		!apiclients.IsNotFound(err) {
+		return core.NewApplicationError().WithMessage("deleting webhook config: %s", err)
+	}
+
+	return nil
+}
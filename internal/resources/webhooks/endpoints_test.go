@@ -0,0 +1,215 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core/coretest"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients"
+	"github.com/formancehq/operator/v3/internal/resources/apiclients/apiclientstest"
+)
+
+type fakeWebhooksAPI struct {
+	nextID      int
+	configs     map[string]*webhookConfig
+	secrets     map[string]string
+	attempts    []attempt
+	failDeletes bool
+}
+
+func (f *fakeWebhooksAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := apiclientstest.PathSegments(r)
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/configs":
+		data := make([]*webhookConfig, 0)
+		for _, config := range f.configs {
+			if id := r.URL.Query().Get("id"); id != "" && config.ID != id {
+				continue
+			}
+			if endpoint := r.URL.Query().Get("endpoint"); endpoint != "" && config.Endpoint != endpoint {
+				continue
+			}
+			data = append(data, config)
+		}
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": data}})
+	case r.Method == http.MethodPost && r.URL.Path == "/configs":
+		input := webhookConfigInput{}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.nextID++
+		config := &webhookConfig{
+			ID:         fmt.Sprintf("config-%d", f.nextID),
+			Endpoint:   input.Endpoint,
+			EventTypes: input.EventTypes,
+			Active:     true,
+		}
+		f.configs[config.ID] = config
+		f.secrets[config.ID] = input.Secret
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"data": config})
+	case r.Method == http.MethodGet && r.URL.Path == "/attempts":
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"cursor": map[string]any{"data": f.attempts}})
+	case len(path) >= 2 && path[0] == "configs" && f.configs[path[1]] != nil:
+		config := f.configs[path[1]]
+		switch {
+		case r.Method == http.MethodDelete && f.failDeletes:
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case r.Method == http.MethodDelete:
+			delete(f.configs, config.ID)
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/secret/change"):
+			input := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&input)
+			f.secrets[config.ID] = input["secret"]
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/deactivate"):
+			config.Active = false
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/activate"):
+			config.Active = true
+		}
+		apiclientstest.WriteJSON(w, http.StatusOK, map[string]any{"data": config})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var attemptsTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type endpointTest struct {
+	t         *testing.T
+	api       *fakeWebhooksAPI
+	apiClient *apiclients.Client
+	endpoint  *v1beta1.WebhookEndpoint
+}
+
+func (e endpointTest) tryReconcile(secret string) error {
+	e.t.Helper()
+	input := webhookConfigInput{
+		Endpoint:   e.endpoint.Spec.URL,
+		EventTypes: e.endpoint.Spec.EventTypes,
+		Secret:     secret,
+	}
+	hash, err := hashEndpointConfig(input)
+	require.NoError(e.t, err)
+	return reconcileEndpoint(coretest.NewContext(), e.apiClient, e.endpoint, input, hash)
+}
+
+func (e endpointTest) reconcile(secret string) {
+	e.t.Helper()
+	require.NoError(e.t, e.tryReconcile(secret))
+}
+
+// newEndpointTest returns a test whose endpoint has already been created on the fake API
+func newEndpointTest(t *testing.T) endpointTest {
+	t.Helper()
+
+	api := &fakeWebhooksAPI{
+		configs: map[string]*webhookConfig{},
+		secrets: map[string]string{},
+		attempts: []attempt{
+			{CreatedAt: attemptsTime.Add(-2 * time.Minute), Status: "success"},
+			{CreatedAt: attemptsTime, Status: "to retry"},
+			{CreatedAt: attemptsTime.Add(-time.Minute), Status: "failed"},
+		},
+	}
+	ret := endpointTest{
+		t:         t,
+		api:       api,
+		apiClient: apiclientstest.NewClient(t, api),
+		endpoint: &v1beta1.WebhookEndpoint{
+			Spec: v1beta1.WebhookEndpointSpec{
+				URL:        "https://example.com/hook",
+				EventTypes: []string{"ledger.committed_transactions"},
+			},
+		},
+	}
+	ret.reconcile("secret1")
+
+	return ret
+}
+
+func TestReconcileEndpointCreate(t *testing.T) {
+	t.Parallel()
+
+	test := newEndpointTest(t)
+
+	configID := test.endpoint.Status.ConfigID
+	require.NotEmpty(t, configID)
+	require.Equal(t, "secret1", test.api.secrets[configID])
+	require.Equal(t, 2, test.endpoint.Status.FailureCount)
+	require.Equal(t, "to retry", test.endpoint.Status.LastAttemptStatus)
+	require.True(t, test.endpoint.Status.LastAttempt.Time.Equal(attemptsTime))
+}
+
+func TestReconcileEndpointSecret(t *testing.T) {
+	t.Parallel()
+
+	test := newEndpointTest(t)
+	configID := test.endpoint.Status.ConfigID
+
+	// The config is kept
+	test.reconcile("secret2")
+	require.Equal(t, configID, test.endpoint.Status.ConfigID)
+	require.Equal(t, "secret2", test.api.secrets[configID])
+}
+
+func TestReconcileEndpointPaused(t *testing.T) {
+	t.Parallel()
+
+	test := newEndpointTest(t)
+
+	test.endpoint.Spec.Paused = true
+	test.reconcile("secret1")
+	require.False(t, test.api.configs[test.endpoint.Status.ConfigID].Active)
+}
+
+func TestReconcileEndpointEventTypes(t *testing.T) {
+	t.Parallel()
+
+	test := newEndpointTest(t)
+	configID := test.endpoint.Status.ConfigID
+
+	// The config is replaced
+	test.endpoint.Spec.EventTypes = []string{"payments.saved_payment"}
+	test.reconcile("secret1")
+	require.NotEqual(t, configID, test.endpoint.Status.ConfigID)
+	require.Len(t, test.api.configs, 1)
+	require.Equal(t, []string{"payments.saved_payment"}, test.api.configs[test.endpoint.Status.ConfigID].EventTypes)
+}
+
+func TestReconcileEndpointDeletionRetried(t *testing.T) {
+	t.Parallel()
+
+	test := newEndpointTest(t)
+	configID := test.endpoint.Status.ConfigID
+
+	test.api.failDeletes = true
+	test.endpoint.Spec.EventTypes = []string{"payments.saved_payment"}
+	require.Error(t, test.tryReconcile("secret1"))
+	require.Equal(t, []string{configID}, test.endpoint.Status.PendingDeletion)
+	require.Len(t, test.api.configs, 2)
+
+	test.api.failDeletes = false
+	test.reconcile("secret1")
+	require.Empty(t, test.endpoint.Status.PendingDeletion)
+	require.Len(t, test.api.configs, 1)
+	require.NotNil(t, test.api.configs[test.endpoint.Status.ConfigID])
+}
+
+func TestReconcileEndpointStatusLost(t *testing.T) {
+	t.Parallel()
+
+	test := newEndpointTest(t)
+	configID := test.endpoint.Status.ConfigID
+
+	// A new config is not created
+	test.endpoint.Status = v1beta1.WebhookEndpointStatus{}
+	test.reconcile("secret1")
+	require.Equal(t, configID, test.endpoint.Status.ConfigID)
+	require.Len(t, test.api.configs, 1)
+}
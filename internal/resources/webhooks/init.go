@@ -33,6 +33,9 @@ import (
 //+kubebuilder:rbac:groups=formance.com,resources=webhooks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=formance.com,resources=webhooks/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=formance.com,resources=webhooks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=formance.com,resources=webhookendpoints,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=formance.com,resources=webhookendpoints/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=formance.com,resources=webhookendpoints/finalizers,verbs=update
 
 func Reconcile(ctx Context, stack *v1beta1.Stack, webhooks *v1beta1.Webhooks, version string) error {
 	database, err := databases.Create(ctx, stack, webhooks)
@@ -50,7 +53,7 @@ func Reconcile(ctx Context, stack *v1beta1.Stack, webhooks *v1beta1.Webhooks, ve
 	}
 
 	if !database.Status.Ready {
-		return NewPendingError().WithMessage("database not ready")
+		return NewPendingError().WithMessage("database not ready").OnlyOnWatchEvent()
 	}
 
 	image, err := registries.GetFormanceImage(ctx, stack, "webhooks", version)
@@ -92,5 +95,12 @@ func init() {
 			databases.Watch[*v1beta1.Webhooks](),
 			brokers.Watch[*v1beta1.Webhooks](),
 		),
+		WithStackDependencyReconciler(ReconcileEndpoint,
+			WithFinalizer[*v1beta1.WebhookEndpoint]("delete-config", CleanEndpoint),
+			WithOwn[*v1beta1.WebhookEndpoint](&v1beta1.ResourceReference{}),
+			WithWatchDependency[*v1beta1.WebhookEndpoint](&v1beta1.Webhooks{}),
+			WithWatchDependency[*v1beta1.WebhookEndpoint](&v1beta1.AuthClient{}),
+			WithRequeueAfter[*v1beta1.WebhookEndpoint](endpointsRefreshInterval),
+		),
 	)
 }
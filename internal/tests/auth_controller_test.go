@@ -66,6 +66,14 @@ var _ = Describe("AuthController", func() {
 					core.Env("BASE_URL", "http://auth:8080"),
 				))
 			})
+			By("Should not generate a signing key", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(LoadResource(stack.Name, "auth", deployment)).To(Succeed())
+				for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+					Expect(env.Name).NotTo(Equal("SIGNING_KEY"))
+				}
+				Expect(LoadResource(stack.Name, "auth-signing-key", &corev1.Secret{})).To(BeNotFound())
+			})
 			By("Should create a pdb", func() {
 				pdb := &v1.PodDisruptionBudget{}
 				Eventually(func() error {
@@ -96,6 +104,34 @@ var _ = Describe("AuthController", func() {
 				}).Should(BeTrue())
 			})
 		})
+		Context("With a signing key algorithm", func() {
+			BeforeEach(func() {
+				auth.Spec.SigningKeyAlgorithm = "RS256"
+			})
+			It("Should generate a signing key", func() {
+				secret := &corev1.Secret{}
+				Eventually(func() error {
+					return LoadResource(stack.Name, "auth-signing-key", secret)
+				}).Should(Succeed())
+				Expect(secret).To(BeControlledBy(auth))
+				Expect(secret.Data).To(HaveKey("signing-key"))
+
+				deployment := &appsv1.Deployment{}
+				Eventually(func(g Gomega) []corev1.EnvVar {
+					g.Expect(LoadResource(stack.Name, "auth", deployment)).To(Succeed())
+					return deployment.Spec.Template.Spec.Containers[0].Env
+				}).Should(ContainElements(
+					core.EnvFromSecret("SIGNING_KEY", "auth-signing-key", "signing-key"),
+				))
+				Expect(deployment.Spec.Template.Annotations).To(HaveKey("signing-key-hash"))
+
+				Eventually(func(g Gomega) *v1beta1.SigningKeyStatus {
+					g.Expect(LoadResource("", auth.Name, auth)).To(Succeed())
+					return auth.Status.SigningKey
+				}).ShouldNot(BeNil())
+				Expect(auth.Status.SigningKey.Algorithm).To(Equal("RS256"))
+			})
+		})
 		Context("Then when disabling the stack", func() {
 			JustBeforeEach(func() {
 				Eventually(func(g Gomega) *v1beta1.Auth {
@@ -121,6 +157,47 @@ var _ = Describe("AuthController", func() {
 				})
 			})
 		})
+		Context("Then when hibernating the stack", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) *v1beta1.Auth {
+					g.Expect(LoadResource("", auth.Name, auth)).To(Succeed())
+					return auth
+				}).Should(BeReady())
+				patch := client.MergeFrom(stack.DeepCopy())
+				stack.Spec.Hibernated = true
+				Expect(Patch(stack, patch)).To(Succeed())
+			})
+			It("Should scale the deployment to zero and keep the dependents objects", func() {
+				deployment := &appsv1.Deployment{}
+				Eventually(func(g Gomega) int32 {
+					g.Expect(LoadResource(stack.Name, "auth", deployment)).To(Succeed())
+					g.Expect(deployment.Spec.Replicas).NotTo(BeNil())
+					return *deployment.Spec.Replicas
+				}).Should(BeZero())
+				Expect(LoadResource("", core.GetObjectName(stack.Name, "auth"), &v1beta1.GatewayHTTPAPI{})).To(Succeed())
+			})
+			Context("Then when waking up the stack", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) int32 {
+						deployment := &appsv1.Deployment{}
+						g.Expect(LoadResource(stack.Name, "auth", deployment)).To(Succeed())
+						g.Expect(deployment.Spec.Replicas).NotTo(BeNil())
+						return *deployment.Spec.Replicas
+					}).Should(BeZero())
+					patch := client.MergeFrom(stack.DeepCopy())
+					stack.Spec.Hibernated = false
+					Expect(Patch(stack, patch)).To(Succeed())
+				})
+				It("Should scale the deployment up", func() {
+					Eventually(func(g Gomega) int32 {
+						deployment := &appsv1.Deployment{}
+						g.Expect(LoadResource(stack.Name, "auth", deployment)).To(Succeed())
+						g.Expect(deployment.Spec.Replicas).NotTo(BeNil())
+						return *deployment.Spec.Replicas
+					}).Should(BeNumerically(">", 0))
+				})
+			})
+		})
 		When("Creating an AuthClient object", func() {
 			var (
 				authClient *v1beta1.AuthClient
@@ -234,6 +311,34 @@ clients:
 				Expect(deployment.Spec.Template.Annotations["auth-clients-secrets"]).ToNot(BeEmpty())
 			})
 		})
+		Context("with a delegated OIDC server preset", func() {
+			BeforeEach(func() {
+				auth.Spec.DelegatedOIDCServer = &v1beta1.DelegatedOIDCServerConfiguration{
+					Preset: &v1beta1.OIDCProviderPreset{
+						Type:  v1beta1.OIDCProviderKeycloak,
+						URL:   "http://keycloak.invalid",
+						Realm: "formance",
+					},
+					ClientID: "formance",
+				}
+			})
+			It("Should configure the deployment using the preset and report the discovery state", func() {
+				deployment := &appsv1.Deployment{}
+				Eventually(func(g Gomega) []corev1.EnvVar {
+					g.Expect(LoadResource(stack.Name, "auth", deployment)).To(Succeed())
+					return deployment.Spec.Template.Spec.Containers[0].Env
+				}).Should(ContainElements(
+					core.Env("DELEGATED_ISSUER", "http://keycloak.invalid/realms/formance"),
+					core.Env("DELEGATED_SCOPES", "openid profile email"),
+					core.Env("DELEGATED_EMAIL_CLAIM", "email"),
+				))
+				Eventually(func(g Gomega) *v1beta1.Condition {
+					g.Expect(LoadResource("", auth.Name, auth)).To(Succeed())
+					return auth.GetConditions().Get("OIDCReachable")
+				}).ShouldNot(BeNil())
+				Expect(auth.GetConditions().Get("OIDCReachable").Status).To(Equal(metav1.ConditionFalse))
+			})
+		})
 		Context("with a Gateway", func() {
 			var (
 				gateway *v1beta1.Gateway
@@ -2,11 +2,13 @@ package tests_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	. "github.com/formancehq/operator/v3/internal/tests/internal"
@@ -48,5 +50,27 @@ var _ = Describe("AuthClientController", func() {
 				return LoadResource(stack.Name, fmt.Sprintf("auth-client-%s", authClient.Name), secret)
 			}).Should(Succeed())
 		})
+		Context("With rotation enabled", func() {
+			BeforeEach(func() {
+				authClient.Spec.Rotation = &v1beta1.AuthClientRotation{
+					Interval: metav1.Duration{Duration: 24 * time.Hour},
+					Overlap:  metav1.Duration{Duration: time.Hour},
+				}
+			})
+			It("Should use the initial secret and report the rotation in the status", func() {
+				secret := &corev1.Secret{}
+				Eventually(func(g Gomega) string {
+					g.Expect(LoadResource(stack.Name, fmt.Sprintf("auth-client-%s", authClient.Name), secret)).To(Succeed())
+					return string(secret.Data["secret"])
+				}).Should(Equal(authClient.Spec.Secret))
+				Expect(secret.Data).NotTo(HaveKey("previous-secret"))
+
+				Eventually(func(g Gomega) *metav1.Time {
+					g.Expect(LoadResource("", authClient.Name, authClient)).To(Succeed())
+					return authClient.Status.LastRotation
+				}).ShouldNot(BeNil())
+				Expect(authClient.Status.Hash).NotTo(BeEmpty())
+			})
+		})
 	})
 })
@@ -0,0 +1,149 @@
+package tests_test
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+	. "github.com/formancehq/operator/v3/internal/tests/internal"
+)
+
+var _ = Describe("CustomModuleController", func() {
+	Context("When creating a CustomModule object", func() {
+		var (
+			stack            *v1beta1.Stack
+			customModule     *v1beta1.CustomModule
+			databaseSettings *v1beta1.Settings
+		)
+		BeforeEach(func() {
+			stack = &v1beta1.Stack{
+				ObjectMeta: RandObjectMeta(),
+				Spec:       v1beta1.StackSpec{Version: "v99.0.0"},
+			}
+			databaseSettings = settings.New(uuid.NewString(), "postgres.*.uri", "postgresql://localhost", stack.Name)
+			customModule = &v1beta1.CustomModule{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.CustomModuleSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					Name:  "reporting",
+					Image: "registry.example.com/reporting:v1.0.0",
+					Env: []corev1.EnvVar{
+						core.Env("FOO", "bar"),
+					},
+					AuthClient: &v1beta1.CustomModuleAuthClientConfig{
+						Scopes: []string{"ledger:read"},
+					},
+					Gateway: &v1beta1.CustomModuleGatewayConfig{
+						Rules: []v1beta1.GatewayHTTPAPIRule{{
+							Path: "/public",
+						}},
+					},
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			Expect(Create(stack, databaseSettings, customModule)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(Delete(stack, databaseSettings)).To(Succeed())
+		})
+		It("Should create resources", func() {
+			By("Should add an owner reference on the stack", func() {
+				Eventually(func(g Gomega) bool {
+					g.Expect(LoadResource("", customModule.Name, customModule)).To(Succeed())
+					reference, err := core.HasOwnerReference(TestContext(), stack, customModule)
+					g.Expect(err).To(BeNil())
+					return reference
+				}).Should(BeTrue())
+			})
+			By("Should create a deployment", func() {
+				deployment := &appsv1.Deployment{}
+				Eventually(func() error {
+					return LoadResource(stack.Name, "reporting", deployment)
+				}).Should(Succeed())
+				Expect(deployment).To(BeControlledBy(customModule))
+				Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.example.com/reporting:v1.0.0"))
+				Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+					core.Env("FOO", "bar"),
+					core.EnvFromSecret("STACK_CLIENT_ID", core.GetObjectName(stack.Name, "reporting"), "id"),
+				))
+			})
+			By("Should create a new GatewayHTTPAPI object", func() {
+				httpAPI := &v1beta1.GatewayHTTPAPI{}
+				Eventually(func() error {
+					return LoadResource("", core.GetObjectName(stack.Name, "reporting"), httpAPI)
+				}).Should(Succeed())
+				Expect(httpAPI).To(BeControlledBy(customModule))
+				Expect(httpAPI.Spec.Name).To(Equal("reporting"))
+				Expect(httpAPI.Spec.Rules).To(Equal(customModule.Spec.Gateway.Rules))
+			})
+			By("Should create a new AuthClient object", func() {
+				authClient := &v1beta1.AuthClient{}
+				Eventually(func() error {
+					return LoadResource("", core.GetObjectName(stack.Name, "reporting"), authClient)
+				}).Should(Succeed())
+				Expect(authClient.Spec.Scopes).To(Equal([]string{"ledger:read"}))
+			})
+		})
+		Context("With a database", func() {
+			BeforeEach(func() {
+				customModule.Spec.Database = true
+			})
+			It("Should create a new Database object", func() {
+				database := &v1beta1.Database{}
+				Eventually(func() error {
+					return LoadResource("", core.GetObjectName(stack.Name, "reporting"), database)
+				}).Should(Succeed())
+				Expect(database).To(BeControlledBy(customModule))
+				Expect(database.Spec.Service).To(Equal("reporting"))
+			})
+		})
+		Context("With the name of a module of the operator", func() {
+			BeforeEach(func() {
+				customModule.Spec.Name = "ledger"
+			})
+			It("Should be rejected", func() {
+				Eventually(func(g Gomega) string {
+					g.Expect(LoadResource("", customModule.Name, customModule)).To(Succeed())
+					return customModule.Status.Info
+				}).Should(ContainSubstring("used by a module of the operator"))
+				Expect(LoadResource(stack.Name, "ledger", &appsv1.Deployment{})).NotTo(Succeed())
+			})
+		})
+		Context("With the name of another custom module", func() {
+			var otherModule *v1beta1.CustomModule
+			JustBeforeEach(func() {
+				Eventually(func() error {
+					return LoadResource(stack.Name, "reporting", &appsv1.Deployment{})
+				}).Should(Succeed())
+				otherModule = &v1beta1.CustomModule{
+					ObjectMeta: RandObjectMeta(),
+					Spec:       customModule.Spec,
+				}
+				// Modules created during the same second are ordered by name
+				otherModule.Name = "z" + otherModule.Name
+				Expect(Create(otherModule)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(otherModule)).To(Succeed())
+			})
+			It("Should reject the newest module", func() {
+				Eventually(func(g Gomega) string {
+					g.Expect(LoadResource("", otherModule.Name, otherModule)).To(Succeed())
+					return otherModule.Status.Info
+				}).Should(ContainSubstring("already used by the custom module"))
+
+				deployment := &appsv1.Deployment{}
+				Expect(LoadResource(stack.Name, "reporting", deployment)).To(Succeed())
+				Expect(deployment).To(BeControlledBy(customModule))
+			})
+		})
+	})
+})
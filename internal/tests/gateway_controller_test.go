@@ -308,6 +308,82 @@ var _ = Describe("GatewayController", func() {
 				})
 			})
 		})
+		Context("With scopes required on a rule and an auth module", func() {
+			var (
+				auth            *v1beta1.Auth
+				jwtAuthSettings *v1beta1.Settings
+			)
+			BeforeEach(func() {
+				jwtAuthSettings = settings.New(uuid.NewString(), "gateway.caddyfile.jwtauth.enabled", "true", stack.Name)
+				Expect(Create(jwtAuthSettings)).To(Succeed())
+				auth = &v1beta1.Auth{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.AuthSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				}
+				httpAPI.Spec.Rules = []v1beta1.GatewayHTTPAPIRule{{
+					Path:    "/",
+					Secured: true,
+					Scopes:  []string{"ledger:read"},
+					Claims: []v1beta1.GatewayHTTPAPIClaimMatcher{{
+						Claim:  "org",
+						Values: []string{"formance"},
+					}},
+				}}
+				Expect(Create(auth)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(auth)).To(Succeed())
+				Expect(Delete(jwtAuthSettings)).To(Succeed())
+			})
+			It("Should enforce the authorization in the Caddyfile", func() {
+				Eventually(func(g Gomega) string {
+					cm := &corev1.ConfigMap{}
+					g.Expect(LoadResource(stack.Name, "gateway", cm)).To(Succeed())
+					return cm.Data["Caddyfile"]
+				}).Should(And(
+					ContainSubstring("order jwtauth before basicauth"),
+					ContainSubstring("jwk_url http://auth:8080/keys"),
+					ContainSubstring("meta_claims scope org"),
+					ContainSubstring(`{http.auth.user.org} in ['formance']`),
+				))
+			})
+		})
+		Context("With scopes required on a rule without the jwtauth caddy module", func() {
+			var auth *v1beta1.Auth
+			BeforeEach(func() {
+				auth = &v1beta1.Auth{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.AuthSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				}
+				httpAPI.Spec.Rules = []v1beta1.GatewayHTTPAPIRule{{
+					Path:    "/",
+					Secured: true,
+					Scopes:  []string{"ledger:read"},
+				}}
+				Expect(Create(auth)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(auth)).To(Succeed())
+			})
+			It("Should deny the route in the Caddyfile", func() {
+				Eventually(func(g Gomega) string {
+					cm := &corev1.ConfigMap{}
+					g.Expect(LoadResource(stack.Name, "gateway", cm)).To(Succeed())
+					return cm.Data["Caddyfile"]
+				}).Should(And(
+					ContainSubstring(`respond "Forbidden" 403`),
+					Not(ContainSubstring("jwtauth")),
+				))
+			})
+		})
 		Context("With otlp enabled", func() {
 			var otelTracesDSNSetting *v1beta1.Settings
 			JustBeforeEach(func() {
@@ -116,6 +116,43 @@ var _ = Describe("LedgerController", func() {
 				Expect(deployment.Generation).ToNot(Equal(cp.Generation))
 			})
 		})
+		Context("with scopes added on the GatewayHTTPAPI object", func() {
+			JustBeforeEach(func() {
+				httpAPI := &v1beta1.GatewayHTTPAPI{}
+				Eventually(func() error {
+					return LoadResource("", core.GetObjectName(stack.Name, "ledger"), httpAPI)
+				}).Should(Succeed())
+
+				patch := client.MergeFrom(httpAPI.DeepCopy())
+				httpAPI.Spec.Rules[0].Scopes = []string{"ledger:read"}
+				httpAPI.Spec.Rules = append(httpAPI.Spec.Rules, v1beta1.GatewayHTTPAPIRule{
+					Path:   "/v2/admin",
+					Scopes: []string{"ledger:admin"},
+				})
+				Expect(Patch(httpAPI, patch)).To(Succeed())
+
+				// Reconcile the module
+				patch = client.MergeFrom(ledger.DeepCopy())
+				ledger.Spec.Version = "v2.2.0"
+				Expect(Patch(ledger, patch)).To(Succeed())
+			})
+			It("Should keep the scopes when the module is reconciled", func() {
+				Eventually(func(g Gomega) string {
+					deployment := &appsv1.Deployment{}
+					g.Expect(Get(core.GetNamespacedResourceName(stack.Name, "ledger"), deployment)).To(Succeed())
+					return deployment.Spec.Template.Spec.Containers[0].Image
+				}).Should(ContainSubstring("v2.2.0"))
+
+				httpAPI := &v1beta1.GatewayHTTPAPI{}
+				Consistently(func(g Gomega) []v1beta1.GatewayHTTPAPIRule {
+					g.Expect(LoadResource("", core.GetObjectName(stack.Name, "ledger"), httpAPI)).To(Succeed())
+					return httpAPI.Spec.Rules
+				}).Should(ConsistOf(
+					v1beta1.GatewayHTTPAPIRule{Scopes: []string{"ledger:read"}},
+					v1beta1.GatewayHTTPAPIRule{Path: "/v2/admin", Scopes: []string{"ledger:admin"}},
+				))
+			})
+		})
 		Context("with a BrokerTopic object existing on the ledger service", func() {
 			deploymentShouldBeConfigured := func() {
 				deployment := &appsv1.Deployment{}
@@ -7,6 +7,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	core "github.com/formancehq/operator/v3/internal/core"
@@ -136,5 +138,47 @@ var _ = Describe("OrchestrationController", func() {
 				}).Should(BeOwnedBy(orchestration))
 			})
 		})
+		Context("With a temporal TLS secret", func() {
+			var (
+				secret            *corev1.Secret
+				tlsSecretSettings *v1beta1.Settings
+			)
+			BeforeEach(func() {
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: "default",
+						Labels: map[string]string{
+							v1beta1.StackLabel: stack.Name,
+						},
+					},
+					Type: corev1.SecretTypeTLS,
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       []byte("cert"),
+						corev1.TLSPrivateKeyKey: []byte("key"),
+					},
+				}
+				tlsSecretSettings = settings.New(uuid.NewString(), "temporal.tls.secret", secret.Name, stack.Name)
+			})
+			JustBeforeEach(func() {
+				Expect(Create(secret)).To(Succeed())
+				Expect(Create(tlsSecretSettings)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(tlsSecretSettings)).To(Succeed())
+				Expect(Delete(secret)).To(Succeed())
+			})
+			It("Should load the certificate from the secret", func() {
+				deployment := &appsv1.Deployment{}
+				Eventually(func(g Gomega) []corev1.EnvVar {
+					g.Expect(LoadResource(stack.Name, "orchestration", deployment)).To(Succeed())
+					return deployment.Spec.Template.Spec.Containers[0].Env
+				}).Should(ContainElements(
+					core.EnvFromSecret("TEMPORAL_SSL_CLIENT_KEY", secret.Name, corev1.TLSPrivateKeyKey),
+					core.EnvFromSecret("TEMPORAL_SSL_CLIENT_CERT", secret.Name, corev1.TLSCertKey),
+				))
+				Expect(deployment.Spec.Template.Annotations).To(HaveKey("temporal-tls-secret-hash"))
+			})
+		})
 	})
 })
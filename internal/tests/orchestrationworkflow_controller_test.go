@@ -0,0 +1,126 @@
+package tests_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	. "github.com/formancehq/operator/v3/internal/tests/internal"
+)
+
+var _ = Describe("OrchestrationWorkflowController", func() {
+	Context("When creating an OrchestrationWorkflow object", func() {
+		var (
+			stack    *v1beta1.Stack
+			workflow *v1beta1.OrchestrationWorkflow
+			trigger  *v1beta1.OrchestrationTrigger
+		)
+		BeforeEach(func() {
+			stack = &v1beta1.Stack{
+				ObjectMeta: RandObjectMeta(),
+				Spec:       v1beta1.StackSpec{Version: "v99.0.0"},
+			}
+			workflow = &v1beta1.OrchestrationWorkflow{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.OrchestrationWorkflowSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					Definition: "stages:\n- delay:\n    duration: 1m\n",
+				},
+			}
+			trigger = &v1beta1.OrchestrationTrigger{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.OrchestrationTriggerSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					Event:    "SAVED_PAYMENT",
+					Workflow: workflow.Name,
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			Expect(Create(stack, workflow, trigger)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(Delete(stack)).To(Succeed())
+		})
+		It("Should report the missing orchestration module", func() {
+			Eventually(func(g Gomega) string {
+				g.Expect(LoadResource("", workflow.Name, workflow)).To(Succeed())
+				return workflow.Status.Info
+			}).Should(ContainSubstring("orchestration module not found on stack"))
+		})
+		It("Should wait for the workflow on the trigger", func() {
+			Eventually(func(g Gomega) string {
+				g.Expect(LoadResource("", trigger.Name, trigger)).To(Succeed())
+				return trigger.Status.Info
+			}).Should(ContainSubstring("waiting for workflow"))
+		})
+		It("Should be deleted without the orchestration module", func() {
+			Eventually(func(g Gomega) []string {
+				g.Expect(LoadResource("", workflow.Name, workflow)).To(Succeed())
+				return workflow.Finalizers
+			}).ShouldNot(BeEmpty())
+			Expect(Delete(trigger, workflow)).To(Succeed())
+			Eventually(func() error {
+				return LoadResource("", workflow.Name, &v1beta1.OrchestrationWorkflow{})
+			}).Should(BeNotFound())
+			Eventually(func() error {
+				return LoadResource("", trigger.Name, &v1beta1.OrchestrationTrigger{})
+			}).Should(BeNotFound())
+		})
+		Context("With a trigger on a workflow of another stack", func() {
+			var otherWorkflow *v1beta1.OrchestrationWorkflow
+			BeforeEach(func() {
+				otherWorkflow = &v1beta1.OrchestrationWorkflow{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.OrchestrationWorkflowSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: "other",
+						},
+						Definition: "stages: []\n",
+					},
+				}
+				Expect(Create(otherWorkflow)).To(Succeed())
+				trigger.Spec.Workflow = otherWorkflow.Name
+			})
+			AfterEach(func() {
+				Expect(Delete(otherWorkflow)).To(Succeed())
+			})
+			It("Should reject the workflow", func() {
+				Eventually(func(g Gomega) string {
+					g.Expect(LoadResource("", trigger.Name, trigger)).To(Succeed())
+					return trigger.Status.Info
+				}).Should(ContainSubstring("is not part of stack"))
+			})
+		})
+		Context("With an orchestration module", func() {
+			var orchestration *v1beta1.Orchestration
+			BeforeEach(func() {
+				orchestration = &v1beta1.Orchestration{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.OrchestrationSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				}
+				Expect(Create(orchestration)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(orchestration)).To(Succeed())
+			})
+			It("Should add an owner reference on the stack", func() {
+				Eventually(func(g Gomega) bool {
+					g.Expect(LoadResource("", workflow.Name, workflow)).To(Succeed())
+					reference, err := core.HasOwnerReference(TestContext(), stack, workflow)
+					g.Expect(err).To(BeNil())
+					return reference
+				}).Should(BeTrue())
+			})
+		})
+	})
+})
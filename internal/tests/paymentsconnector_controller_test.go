@@ -0,0 +1,125 @@
+package tests_test
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+	. "github.com/formancehq/operator/v3/internal/tests/internal"
+)
+
+var _ = Describe("PaymentsConnectorController", func() {
+	Context("When creating a PaymentsConnector object", func() {
+		var (
+			stack     *v1beta1.Stack
+			connector *v1beta1.PaymentsConnector
+		)
+		BeforeEach(func() {
+			stack = &v1beta1.Stack{
+				ObjectMeta: RandObjectMeta(),
+				Spec:       v1beta1.StackSpec{Version: "v99.0.0"},
+			}
+			connector = &v1beta1.PaymentsConnector{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.PaymentsConnectorSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					Provider: "stripe",
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			Expect(Create(stack, connector)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(Delete(stack)).To(Succeed())
+		})
+		It("Should report the missing payments module", func() {
+			Eventually(func(g Gomega) string {
+				g.Expect(LoadResource("", connector.Name, connector)).To(Succeed())
+				return connector.Status.Info
+			}).Should(ContainSubstring("payments module not found on stack"))
+		})
+		It("Should be deleted without the payments module", func() {
+			Eventually(func(g Gomega) []string {
+				g.Expect(LoadResource("", connector.Name, connector)).To(Succeed())
+				return connector.Finalizers
+			}).ShouldNot(BeEmpty())
+			Expect(Delete(connector)).To(Succeed())
+			Eventually(func() error {
+				return LoadResource("", connector.Name, &v1beta1.PaymentsConnector{})
+			}).Should(BeNotFound())
+		})
+		Context("With a payments module and credentials", func() {
+			var (
+				payments            *v1beta1.Payments
+				databaseSettings    *v1beta1.Settings
+				temporalDSNSettings *v1beta1.Settings
+				secret              *corev1.Secret
+			)
+			BeforeEach(func() {
+				payments = &v1beta1.Payments{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.PaymentsSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				}
+				databaseSettings = settings.New(uuid.NewString(), "postgres.*.uri", "postgresql://localhost", stack.Name)
+				temporalDSNSettings = settings.New(uuid.NewString(), "temporal.dsn", "temporal://localhost/namespace", stack.Name)
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: "default",
+						Labels: map[string]string{
+							v1beta1.StackLabel: stack.Name,
+						},
+					},
+					Data: map[string][]byte{
+						"apiKey": []byte("key"),
+					},
+				}
+				connector.Spec.Credentials = map[string]corev1.SecretKeySelector{
+					"apiKey": {
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: "apiKey",
+					},
+				}
+				Expect(Create(databaseSettings, temporalDSNSettings, secret, payments)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(payments, databaseSettings, temporalDSNSettings, secret)).To(Succeed())
+			})
+			It("Should add an owner reference on the stack", func() {
+				Eventually(func(g Gomega) bool {
+					g.Expect(LoadResource("", connector.Name, connector)).To(Succeed())
+					reference, err := core.HasOwnerReference(TestContext(), stack, connector)
+					g.Expect(err).To(BeNil())
+					return reference
+				}).Should(BeTrue())
+			})
+			It("Should copy the credentials in the stack namespace", func() {
+				reference := &v1beta1.ResourceReference{}
+				Eventually(func() error {
+					return LoadResource("", fmt.Sprintf("%s-credentials-%s", connector.Name, secret.Name), reference)
+				}).Should(Succeed())
+				Expect(reference).To(BeControlledBy(connector))
+
+				Eventually(func() error {
+					return LoadResource(stack.Name, secret.Name, &corev1.Secret{})
+				}).Should(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,83 @@
+package tests_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	. "github.com/formancehq/operator/v3/internal/tests/internal"
+)
+
+var _ = Describe("ReconciliationPolicyController", func() {
+	Context("When creating a ReconciliationPolicy object", func() {
+		var (
+			stack  *v1beta1.Stack
+			policy *v1beta1.ReconciliationPolicy
+		)
+		BeforeEach(func() {
+			stack = &v1beta1.Stack{
+				ObjectMeta: RandObjectMeta(),
+				Spec:       v1beta1.StackSpec{Version: "v99.0.0"},
+			}
+			policy = &v1beta1.ReconciliationPolicy{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.ReconciliationPolicySpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					LedgerName:     "default",
+					PaymentsPoolID: "pool",
+					Schedule:       "0 * * * *",
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			Expect(Create(stack, policy)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(Delete(stack)).To(Succeed())
+		})
+		It("Should report the missing reconciliation module", func() {
+			Eventually(func(g Gomega) string {
+				g.Expect(LoadResource("", policy.Name, policy)).To(Succeed())
+				return policy.Status.Info
+			}).Should(ContainSubstring("reconciliation module not found on stack"))
+		})
+		It("Should be deleted without the reconciliation module", func() {
+			Eventually(func(g Gomega) []string {
+				g.Expect(LoadResource("", policy.Name, policy)).To(Succeed())
+				return policy.Finalizers
+			}).ShouldNot(BeEmpty())
+			Expect(Delete(policy)).To(Succeed())
+			Eventually(func() error {
+				return LoadResource("", policy.Name, &v1beta1.ReconciliationPolicy{})
+			}).Should(BeNotFound())
+		})
+		Context("With a reconciliation module", func() {
+			var reconciliation *v1beta1.Reconciliation
+			BeforeEach(func() {
+				reconciliation = &v1beta1.Reconciliation{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.ReconciliationSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				}
+				Expect(Create(reconciliation)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(reconciliation)).To(Succeed())
+			})
+			It("Should add an owner reference on the stack", func() {
+				Eventually(func(g Gomega) bool {
+					g.Expect(LoadResource("", policy.Name, policy)).To(Succeed())
+					reference, err := core.HasOwnerReference(TestContext(), stack, policy)
+					g.Expect(err).To(BeNil())
+					return reference
+				}).Should(BeTrue())
+			})
+		})
+	})
+})
@@ -52,6 +52,13 @@ var _ = Describe("StackController", func() {
 					return stack.Status.Ready
 				}).Should(BeTrue())
 			})
+			By("Should have the standard conditions", func() {
+				Expect(stack.Status.Conditions.Get(v1beta1.ConditionTypeReady)).NotTo(BeNil())
+				Expect(stack.Status.Conditions.Get(v1beta1.ConditionTypeReady).Status).To(Equal(v1.ConditionTrue))
+				Expect(stack.Status.Conditions.Get(v1beta1.ConditionTypeReady).Reason).To(Equal(v1beta1.ReasonReconciled))
+				Expect(stack.Status.Conditions.Get(v1beta1.ConditionTypeProgressing).Status).To(Equal(v1.ConditionFalse))
+				Expect(stack.Status.Conditions.Get(v1beta1.ConditionTypeDegraded).Status).To(Equal(v1.ConditionFalse))
+			})
 		})
 		When("settings are present", func() {
 			var (
@@ -100,6 +107,42 @@ var _ = Describe("StackController", func() {
 				))
 			})
 		})
+		When("resource quota and limit range settings are present", func() {
+			var (
+				resourceQuotaSettings *v1beta1.Settings
+				limitRangeSettings    *v1beta1.Settings
+			)
+			BeforeEach(func() {
+				resourceQuotaSettings = settings.New(uuid.NewString(), "namespace.resource-quota.hard", "pods=10", stack.Name)
+				Expect(Create(resourceQuotaSettings)).To(Succeed())
+
+				limitRangeSettings = settings.New(uuid.NewString(), "namespace.limit-range.limits", "cpu=500m,memory=512Mi", stack.Name)
+				Expect(Create(limitRangeSettings)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(resourceQuotaSettings)).To(Succeed())
+				Expect(Delete(limitRangeSettings)).To(Succeed())
+			})
+			It("Should create a resource quota and report its usage", func() {
+				resourceQuota := &corev1.ResourceQuota{}
+				Eventually(func() error {
+					return LoadResource(stack.Name, "stack-resource-quota", resourceQuota)
+				}).Should(Succeed())
+				Expect(resourceQuota.Spec.Hard).To(HaveKey(corev1.ResourcePods))
+				Eventually(func(g Gomega) *corev1.ResourceQuotaStatus {
+					g.Expect(LoadResource("", stack.Name, stack)).To(Succeed())
+					return stack.Status.ResourceQuota
+				}).ShouldNot(BeNil())
+			})
+			It("Should create a limit range", func() {
+				limitRange := &corev1.LimitRange{}
+				Eventually(func() error {
+					return LoadResource(stack.Name, "stack-limit-range", limitRange)
+				}).Should(Succeed())
+				Expect(limitRange.Spec.Limits).To(HaveLen(1))
+				Expect(limitRange.Spec.Limits[0].Default).To(HaveKey(corev1.ResourceCPU))
+			})
+		})
 		Context("with version specified", func() {
 			BeforeEach(func() {
 				stack.Spec.Version = "1234"
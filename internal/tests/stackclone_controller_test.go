@@ -0,0 +1,139 @@
+package tests_test
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+	"github.com/formancehq/operator/v3/internal/resources/stackclones"
+	. "github.com/formancehq/operator/v3/internal/tests/internal"
+)
+
+var _ = Describe("StackCloneController", func() {
+	Context("When cloning a stack", func() {
+		var (
+			source           *v1beta1.Stack
+			authClient       *v1beta1.AuthClient
+			databaseSettings *v1beta1.Settings
+			userSecret       *corev1.Secret
+			stackClone       *v1beta1.StackClone
+		)
+		BeforeEach(func() {
+			source = &v1beta1.Stack{
+				ObjectMeta: RandObjectMeta(),
+				Spec:       v1beta1.StackSpec{Version: "v99.0.0"},
+			}
+			authClient = &v1beta1.AuthClient{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.AuthClientSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: source.Name,
+					},
+					ID:     uuid.NewString(),
+					Secret: uuid.NewString(),
+				},
+			}
+			databaseSettings = settings.New(uuid.NewString(), "postgres.*.uri", "postgresql://localhost", source.Name)
+			stackClone = &v1beta1.StackClone{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.StackCloneSpec{
+					Source:      source.Name,
+					Destination: RandObjectMeta().Name,
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			Expect(Create(source)).To(Succeed())
+			Expect(Create(databaseSettings)).To(Succeed())
+			Expect(Create(authClient)).To(Succeed())
+			Eventually(func() error {
+				return LoadResource("", source.Name, &corev1.Namespace{})
+			}).Should(Succeed())
+			userSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: source.Name,
+					Name:      "user-secret",
+				},
+				StringData: map[string]string{
+					"key": "value",
+				},
+			}
+			Expect(Create(userSecret)).To(Succeed())
+			Expect(Create(stackClone)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(Delete(stackClone)).To(Succeed())
+			Expect(Delete(authClient)).To(Succeed())
+			Expect(Delete(databaseSettings)).To(Succeed())
+			Expect(Delete(source)).To(Succeed())
+		})
+		It("Should create the destination stack with the resources of the source stack", func() {
+			Eventually(func(g Gomega) *metav1.Time {
+				g.Expect(LoadResource("", stackClone.Name, stackClone)).To(Succeed())
+				return stackClone.Status.CompletedAt
+			}).ShouldNot(BeNil())
+
+			destination := &v1beta1.Stack{}
+			Expect(LoadResource("", stackClone.Spec.Destination, destination)).To(Succeed())
+			Expect(destination.Spec).To(Equal(source.Spec))
+			Expect(destination.Annotations).To(HaveKeyWithValue(stackclones.ClonedByAnnotation, stackClone.Name))
+
+			clonedSettings := &v1beta1.Settings{}
+			Expect(LoadResource("", stackclones.GetClonedName(stackClone, databaseSettings.Name), clonedSettings)).To(Succeed())
+			Expect(clonedSettings.Spec.Stacks).To(Equal([]string{stackClone.Spec.Destination}))
+			Expect(clonedSettings.Spec.Key).To(Equal(databaseSettings.Spec.Key))
+
+			clonedAuthClient := &v1beta1.AuthClient{}
+			Expect(LoadResource("", stackclones.GetClonedName(stackClone, authClient.Name), clonedAuthClient)).To(Succeed())
+			Expect(clonedAuthClient.Spec.Stack).To(Equal(stackClone.Spec.Destination))
+			Expect(clonedAuthClient.Spec.Secret).To(Equal(authClient.Spec.Secret))
+
+			clonedSecret := &corev1.Secret{}
+			Expect(LoadResource(stackClone.Spec.Destination, userSecret.Name, clonedSecret)).To(Succeed())
+			Expect(clonedSecret.Data).To(HaveKeyWithValue("key", []byte("value")))
+
+			Expect(stackClone.Status.ClonedResources).To(ContainElements(
+				"Stack/"+stackClone.Spec.Destination,
+				"AuthClient/"+clonedAuthClient.Name,
+			))
+		})
+		Context("With auth secrets regeneration", func() {
+			BeforeEach(func() {
+				stackClone.Spec.RegenerateAuthSecrets = true
+			})
+			It("Should generate a new secret for the auth clients", func() {
+				clonedAuthClient := &v1beta1.AuthClient{}
+				Eventually(func() error {
+					return LoadResource("", stackclones.GetClonedName(stackClone, authClient.Name), clonedAuthClient)
+				}).Should(Succeed())
+				Expect(clonedAuthClient.Spec.Secret).NotTo(BeEmpty())
+				Expect(clonedAuthClient.Spec.Secret).NotTo(Equal(authClient.Spec.Secret))
+			})
+		})
+		Context("With a destination stack already existing", func() {
+			var existing *v1beta1.Stack
+			BeforeEach(func() {
+				existing = &v1beta1.Stack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: stackClone.Spec.Destination,
+					},
+				}
+				Expect(Create(existing)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(existing)).To(Succeed())
+			})
+			It("Should report an error", func() {
+				Eventually(func(g Gomega) string {
+					g.Expect(LoadResource("", stackClone.Name, stackClone)).To(Succeed())
+					return stackClone.Status.Info
+				}).Should(ContainSubstring("already exists"))
+				Expect(stackClone.Status.CompletedAt).To(BeNil())
+			})
+		})
+	})
+})
@@ -1,9 +1,12 @@
 package tests_test
 
 import (
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
 	"github.com/formancehq/operator/v3/internal/core"
@@ -61,6 +64,49 @@ var _ = Describe("StargateController", func() {
 					return LoadResource(stack.Name, "stargate", deployment)
 				}).Should(Succeed())
 				Expect(deployment).To(BeControlledBy(stargate))
+				Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(core.Env("STARGATE_AUTH_CLIENT_SECRET", "client0")))
+			})
+		})
+		Context("With the client secret referenced from a secret", func() {
+			var secret *corev1.Secret
+			BeforeEach(func() {
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: "default",
+						Labels: map[string]string{
+							v1beta1.StackLabel: stack.Name,
+						},
+					},
+					StringData: map[string]string{
+						"secret": "client0",
+					},
+				}
+				Expect(Create(secret)).To(Succeed())
+
+				stargate.Spec.Auth.ClientSecret = ""
+				stargate.Spec.Auth.ClientSecretFromSecret = &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: "secret",
+				}
+			})
+			AfterEach(func() {
+				Expect(Delete(secret)).To(Succeed())
+			})
+			It("Should reference the secret in the deployment", func() {
+				deployment := &appsv1.Deployment{}
+				Eventually(func(g Gomega) []corev1.EnvVar {
+					g.Expect(LoadResource(stack.Name, "stargate", deployment)).To(Succeed())
+					return deployment.Spec.Template.Spec.Containers[0].Env
+				}).Should(ContainElement(corev1.EnvVar{
+					Name: "STARGATE_AUTH_CLIENT_SECRET",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: stargate.Spec.Auth.ClientSecretFromSecret,
+					},
+				}))
+				Expect(deployment.Spec.Template.Annotations).To(HaveKey("stargate-client-secret"))
 			})
 		})
 	})
@@ -0,0 +1,111 @@
+package tests_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	. "github.com/formancehq/operator/v3/internal/tests/internal"
+)
+
+var _ = Describe("WalletController", func() {
+	Context("When creating a Wallet object", func() {
+		var (
+			stack   *v1beta1.Stack
+			wallet  *v1beta1.Wallet
+			balance *v1beta1.WalletBalance
+		)
+		BeforeEach(func() {
+			stack = &v1beta1.Stack{
+				ObjectMeta: RandObjectMeta(),
+				Spec:       v1beta1.StackSpec{Version: "v99.0.0"},
+			}
+			wallet = &v1beta1.Wallet{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.WalletSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					Metadata: map[string]string{
+						"kind": "system",
+					},
+				},
+			}
+			balance = &v1beta1.WalletBalance{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.WalletBalanceSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					Wallet: wallet.Name,
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			Expect(Create(stack, wallet, balance)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(Delete(balance, wallet, stack)).To(Succeed())
+		})
+		It("Should report the missing wallets module", func() {
+			Eventually(func(g Gomega) string {
+				g.Expect(LoadResource("", wallet.Name, wallet)).To(Succeed())
+				return wallet.Status.Info
+			}).Should(ContainSubstring("wallets module not found on stack"))
+		})
+		It("Should wait for the wallet on the balance", func() {
+			Eventually(func(g Gomega) string {
+				g.Expect(LoadResource("", balance.Name, balance)).To(Succeed())
+				return balance.Status.Info
+			}).Should(ContainSubstring("waiting for wallet"))
+		})
+		Context("With a balance on a missing wallet", func() {
+			BeforeEach(func() {
+				balance.Spec.Wallet = "missing"
+			})
+			It("Should report the missing wallet", func() {
+				Eventually(func(g Gomega) string {
+					g.Expect(LoadResource("", balance.Name, balance)).To(Succeed())
+					return balance.Status.Info
+				}).Should(ContainSubstring("wallet missing not found"))
+			})
+		})
+		Context("With a balance on a wallet of another stack", func() {
+			BeforeEach(func() {
+				wallet.Spec.Stack = "other"
+			})
+			It("Should reject the wallet", func() {
+				Eventually(func(g Gomega) string {
+					g.Expect(LoadResource("", balance.Name, balance)).To(Succeed())
+					return balance.Status.Info
+				}).Should(ContainSubstring("is not part of stack"))
+			})
+		})
+		Context("With a wallets module", func() {
+			var wallets *v1beta1.Wallets
+			BeforeEach(func() {
+				wallets = &v1beta1.Wallets{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.WalletsSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				}
+				Expect(Create(wallets)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(wallets)).To(Succeed())
+			})
+			It("Should add an owner reference on the stack", func() {
+				Eventually(func(g Gomega) bool {
+					g.Expect(LoadResource("", wallet.Name, wallet)).To(Succeed())
+					reference, err := core.HasOwnerReference(TestContext(), stack, wallet)
+					g.Expect(err).To(BeNil())
+					return reference
+				}).Should(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,117 @@
+package tests_test
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	. "github.com/formancehq/operator/v3/internal/tests/internal"
+)
+
+var _ = Describe("WebhookEndpointController", func() {
+	Context("When creating a WebhookEndpoint object", func() {
+		var (
+			stack    *v1beta1.Stack
+			endpoint *v1beta1.WebhookEndpoint
+		)
+		BeforeEach(func() {
+			stack = &v1beta1.Stack{
+				ObjectMeta: RandObjectMeta(),
+				Spec:       v1beta1.StackSpec{Version: "v99.0.0"},
+			}
+			endpoint = &v1beta1.WebhookEndpoint{
+				ObjectMeta: RandObjectMeta(),
+				Spec: v1beta1.WebhookEndpointSpec{
+					StackDependency: v1beta1.StackDependency{
+						Stack: stack.Name,
+					},
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"ledger.committed_transactions"},
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			Expect(Create(stack, endpoint)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(Delete(stack)).To(Succeed())
+		})
+		It("Should report the missing webhooks module", func() {
+			Eventually(func(g Gomega) string {
+				g.Expect(LoadResource("", endpoint.Name, endpoint)).To(Succeed())
+				return endpoint.Status.Info
+			}).Should(ContainSubstring("webhooks module not found on stack"))
+		})
+		It("Should be deleted without the webhooks module", func() {
+			Eventually(func(g Gomega) []string {
+				g.Expect(LoadResource("", endpoint.Name, endpoint)).To(Succeed())
+				return endpoint.Finalizers
+			}).ShouldNot(BeEmpty())
+			Expect(Delete(endpoint)).To(Succeed())
+			Eventually(func() error {
+				return LoadResource("", endpoint.Name, &v1beta1.WebhookEndpoint{})
+			}).Should(BeNotFound())
+		})
+		Context("With a webhooks module and a secret", func() {
+			var (
+				webhooks *v1beta1.Webhooks
+				secret   *corev1.Secret
+			)
+			BeforeEach(func() {
+				webhooks = &v1beta1.Webhooks{
+					ObjectMeta: RandObjectMeta(),
+					Spec: v1beta1.WebhooksSpec{
+						StackDependency: v1beta1.StackDependency{
+							Stack: stack.Name,
+						},
+					},
+				}
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: "default",
+						Labels: map[string]string{
+							v1beta1.StackLabel: stack.Name,
+						},
+					},
+					Data: map[string][]byte{
+						"secret": []byte("c2VjcmV0"),
+					},
+				}
+				endpoint.Spec.Secret = &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: "secret",
+				}
+				Expect(Create(secret, webhooks)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(Delete(webhooks, secret)).To(Succeed())
+			})
+			It("Should add an owner reference on the stack", func() {
+				Eventually(func(g Gomega) bool {
+					g.Expect(LoadResource("", endpoint.Name, endpoint)).To(Succeed())
+					reference, err := core.HasOwnerReference(TestContext(), stack, endpoint)
+					g.Expect(err).To(BeNil())
+					return reference
+				}).Should(BeTrue())
+			})
+			It("Should copy the secret in the stack namespace", func() {
+				reference := &v1beta1.ResourceReference{}
+				Eventually(func() error {
+					return LoadResource("", endpoint.Name+"-secret", reference)
+				}).Should(Succeed())
+				Expect(reference).To(BeControlledBy(endpoint))
+
+				Eventually(func() error {
+					return LoadResource(stack.Name, secret.Name, &corev1.Secret{})
+				}).Should(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,38 @@
+// Package applications deploys the applications of the modules
+package applications
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/resources/applications"
+)
+
+type (
+	Application = applications.Application
+	ProbeOpts   = applications.ProbeOpts
+)
+
+// New creates an application from a deployment template.
+// The deployment is configured like the deployments of the built-in modules, using the settings of the stack
+// (resource requirements, replicas, security context, topology spread constraints, hibernation, etc.).
+func New(owner v1beta1.Dependent, deploymentTpl *appsv1.Deployment) *Application {
+	return applications.New(owner, deploymentTpl)
+}
+
+func DefaultLiveness(port string, opts ...ProbeOpts) *corev1.Probe {
+	return applications.DefaultLiveness(port, opts...)
+}
+
+func DefaultReadiness(port string, opts ...ProbeOpts) *corev1.Probe {
+	return applications.DefaultReadiness(port, opts...)
+}
+
+func WithProbePath(path string) ProbeOpts {
+	return applications.WithProbePath(path)
+}
+
+func StandardHTTPPort() corev1.ContainerPort {
+	return applications.StandardHTTPPort()
+}
@@ -0,0 +1,41 @@
+// Package databases manages the databases of the modules
+package databases
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/databases"
+	"github.com/formancehq/operator/v3/internal/resources/jobs"
+	"github.com/formancehq/operator/v3/internal/resources/registries"
+)
+
+type HandleJobOption = jobs.HandleJobOption
+
+// Create creates the database of a module, named after the kind of the owner
+func Create(ctx core.Context, stack *v1beta1.Stack, owner interface {
+	v1beta1.Object
+	IsDebug() bool
+}) (*v1beta1.Database, error) {
+	return databases.Create(ctx, stack, owner)
+}
+
+// GetPostgresEnvVars returns the environment variables used to connect to the database
+func GetPostgresEnvVars(ctx core.Context, stack *v1beta1.Stack, database *v1beta1.Database) ([]corev1.EnvVar, error) {
+	return databases.GetPostgresEnvVars(ctx, stack, database)
+}
+
+// Migrate runs the migrate command of the image in a job
+func Migrate(ctx core.Context, stack *v1beta1.Stack, owner v1beta1.Dependent,
+	imageConfiguration *registries.ImageConfiguration, database *v1beta1.Database, options ...HandleJobOption) error {
+	return databases.Migrate(ctx, stack, owner, imageConfiguration, database, options...)
+}
+
+func SaveModuleVersion(ctx core.Context, database *v1beta1.Database, version string) error {
+	return databases.SaveModuleVersion(ctx, database, version)
+}
+
+func GetSavedModuleVersion(database *v1beta1.Database) string {
+	return databases.GetSavedModuleVersion(database)
+}
@@ -0,0 +1,31 @@
+// Package gatewayhttpapis exposes the modules through the gateway of the stack
+package gatewayhttpapis
+
+import (
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/gatewayhttpapis"
+)
+
+type Option = gatewayhttpapis.Option
+
+// Create routes the requests of the gateway prefixed by the lower cased kind of the module to the service of the module
+func Create(ctx core.Context, owner v1beta1.Module, options ...Option) error {
+	return gatewayhttpapis.Create(ctx, owner, options...)
+}
+
+func WithRules(rules ...v1beta1.GatewayHTTPAPIRule) Option {
+	return gatewayhttpapis.WithRules(rules...)
+}
+
+func WithHealthCheckEndpoint(v string) Option {
+	return gatewayhttpapis.WithHealthCheckEndpoint(v)
+}
+
+func RuleSecured() v1beta1.GatewayHTTPAPIRule {
+	return gatewayhttpapis.RuleSecured()
+}
+
+func RuleUnsecured() v1beta1.GatewayHTTPAPIRule {
+	return gatewayhttpapis.RuleUnsecured()
+}
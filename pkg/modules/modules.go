@@ -0,0 +1,128 @@
+// Package modules is the extension API of the operator.
+//
+// It allows building a custom operator binary with out-of-tree modules, installed on the stacks
+// exactly like the built-in ones. Importing this package registers the built-in modules.
+//
+// A module is a custom resource implementing v1beta1.Module, registered in the scheme of the manager,
+// and reconciled by a reconciler registered with Init:
+//
+//	func init() {
+//		modules.Init(
+//			modules.WithModuleReconciler(Reconcile,
+//				modules.WithWatchSettings[*Reporting](),
+//				modules.WithOwn[*Reporting](&appsv1.Deployment{}),
+//			),
+//		)
+//	}
+package modules
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	_ "github.com/formancehq/operator/v3/internal/resources"
+)
+
+type (
+	Context                           = core.Context
+	Initializer                       = core.Initializer
+	ReconcilerOption[T client.Object] = core.ReconcilerOption[T]
+	Finalizer[T client.Object]        = core.Finalizer[T]
+	ObjectMutator[T any]              = core.ObjectMutator[T]
+	ApplicationError                  = core.ApplicationError
+	Platform                          = core.Platform
+	Sharding                          = core.Sharding
+	StackSelector                     = core.StackSelector
+)
+
+// Init registers reconcilers, they are started by Setup
+func Init(i ...Initializer) {
+	core.Init(i...)
+}
+
+// Setup starts the reconcilers of the built-in modules and of the modules registered with Init
+func Setup(mgr ctrl.Manager, platform Platform) error {
+	return core.Setup(mgr, platform)
+}
+
+// SetupConversionWebhooks registers the conversion webhook of the CRDs available in several versions
+func SetupConversionWebhooks(mgr ctrl.Manager) error {
+	return core.SetupConversionWebhooks(mgr)
+}
+
+// SetupStackHealthEndpoint serves the health of the stacks under /stacks/<name>/health, on a dedicated listener
+// started only when an address is provided
+func SetupStackHealthEndpoint(mgr ctrl.Manager, bindAddress string) error {
+	return core.SetupStackHealthEndpoint(mgr, bindAddress)
+}
+
+// WithModuleReconciler registers the reconciler of a module.
+// The module is installed on the stack referenced by its spec, using the version resolved from the module,
+// the stack or the Versions object of the stack, under the lower cased name of its kind.
+func WithModuleReconciler[T v1beta1.Module](fn func(ctx Context, stack *v1beta1.Stack, req T, version string) error, opts ...ReconcilerOption[T]) Initializer {
+	return core.WithModuleReconciler(fn, opts...)
+}
+
+// WithResourceReconciler registers the reconciler of an object depending on a stack, which is not a module
+func WithResourceReconciler[T v1beta1.Dependent](fn func(ctx Context, stack *v1beta1.Stack, req T) error, opts ...ReconcilerOption[T]) Initializer {
+	return core.WithResourceReconciler(fn, opts...)
+}
+
+func WithOwn[T client.Object](v client.Object, opts ...builder.OwnsOption) ReconcilerOption[T] {
+	return core.WithOwn[T](v, opts...)
+}
+
+func WithWatchSettings[T client.Object]() ReconcilerOption[T] {
+	return core.WithWatchSettings[T]()
+}
+
+func WithWatchDependency[T client.Object](t v1beta1.Dependent) ReconcilerOption[T] {
+	return core.WithWatchDependency[T](t)
+}
+
+func WithWatch[T client.Object, WATCHED client.Object](fn func(ctx Context, object WATCHED) []reconcile.Request) ReconcilerOption[T] {
+	return core.WithWatch[T](fn)
+}
+
+func WithFinalizer[T client.Object](name string, callback Finalizer[T]) ReconcilerOption[T] {
+	return core.WithFinalizer[T](name, callback)
+}
+
+func WithRequeueAfter[T client.Object](d time.Duration) ReconcilerOption[T] {
+	return core.WithRequeueAfter[T](d)
+}
+
+func NewApplicationError() *ApplicationError {
+	return core.NewApplicationError()
+}
+
+func NewPendingError() *ApplicationError {
+	return core.NewPendingError()
+}
+
+func NewMissingSettingsError(msg string) *ApplicationError {
+	return core.NewMissingSettingsError(msg)
+}
+
+// CreateOrUpdate creates or updates an object, applying the mutators
+func CreateOrUpdate[T client.Object](ctx Context, key client.ObjectKey, mutators ...ObjectMutator[T]) (T, controllerutil.OperationResult, error) {
+	return core.CreateOrUpdate[T](ctx, key, mutators...)
+}
+
+// WithController sets the owner as controller of the object
+func WithController[T client.Object](scheme *runtime.Scheme, owner client.Object) ObjectMutator[T] {
+	return core.WithController[T](scheme, owner)
+}
+
+// GetObjectName returns the name of an object of a stack
+func GetObjectName(stack, name string) string {
+	return core.GetObjectName(stack, name)
+}
@@ -0,0 +1,14 @@
+// Package registries resolves the images of the modules
+package registries
+
+import (
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/registries"
+)
+
+type ImageConfiguration = registries.ImageConfiguration
+
+// GetImageConfiguration returns the configuration of an image, applying the registries settings of the stack
+func GetImageConfiguration(ctx core.Context, stackName, image string) (*ImageConfiguration, error) {
+	return registries.GetImageConfiguration(ctx, stackName, image)
+}
@@ -0,0 +1,105 @@
+// Package settings reads the settings of the stacks.
+// Keys are split on dots, and the most specific settings matching the stack and the keys are used.
+package settings
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+	"github.com/formancehq/operator/v3/internal/core"
+	"github.com/formancehq/operator/v3/internal/resources/settings"
+)
+
+func Get(ctx core.Context, stack string, keys ...string) (*string, error) {
+	return settings.Get(ctx, stack, keys...)
+}
+
+func GetString(ctx core.Context, stack string, keys ...string) (*string, error) {
+	return settings.GetString(ctx, stack, keys...)
+}
+
+func GetStringOrDefault(ctx core.Context, stack, defaultValue string, keys ...string) (string, error) {
+	return settings.GetStringOrDefault(ctx, stack, defaultValue, keys...)
+}
+
+func GetStringOrEmpty(ctx core.Context, stack string, keys ...string) (string, error) {
+	return settings.GetStringOrEmpty(ctx, stack, keys...)
+}
+
+func GetStringSlice(ctx core.Context, stack string, keys ...string) ([]string, error) {
+	return settings.GetStringSlice(ctx, stack, keys...)
+}
+
+func RequireString(ctx core.Context, stack string, keys ...string) (string, error) {
+	return settings.RequireString(ctx, stack, keys...)
+}
+
+func GetURL(ctx core.Context, stack string, keys ...string) (*v1beta1.URI, error) {
+	return settings.GetURL(ctx, stack, keys...)
+}
+
+func RequireURL(ctx core.Context, stack string, keys ...string) (*v1beta1.URI, error) {
+	return settings.RequireURL(ctx, stack, keys...)
+}
+
+func GetInt32(ctx core.Context, stack string, keys ...string) (*int32, error) {
+	return settings.GetInt32(ctx, stack, keys...)
+}
+
+func GetInt32OrDefault(ctx core.Context, stack string, defaultValue int32, keys ...string) (int32, error) {
+	return settings.GetInt32OrDefault(ctx, stack, defaultValue, keys...)
+}
+
+func GetIntOrDefault(ctx core.Context, stack string, defaultValue int, keys ...string) (int, error) {
+	return settings.GetIntOrDefault(ctx, stack, defaultValue, keys...)
+}
+
+func GetBool(ctx core.Context, stack string, keys ...string) (*bool, error) {
+	return settings.GetBool(ctx, stack, keys...)
+}
+
+func GetBoolOrDefault(ctx core.Context, stack string, defaultValue bool, keys ...string) (bool, error) {
+	return settings.GetBoolOrDefault(ctx, stack, defaultValue, keys...)
+}
+
+func GetBoolOrFalse(ctx core.Context, stack string, keys ...string) (bool, error) {
+	return settings.GetBoolOrFalse(ctx, stack, keys...)
+}
+
+func GetBoolOrTrue(ctx core.Context, stack string, keys ...string) (bool, error) {
+	return settings.GetBoolOrTrue(ctx, stack, keys...)
+}
+
+func GetMap(ctx core.Context, stack string, keys ...string) (map[string]string, error) {
+	return settings.GetMap(ctx, stack, keys...)
+}
+
+func GetMapOrEmpty(ctx core.Context, stack string, keys ...string) (map[string]string, error) {
+	return settings.GetMapOrEmpty(ctx, stack, keys...)
+}
+
+func GetEnvVars(ctx core.Context, stack string, keys ...string) ([]corev1.EnvVar, error) {
+	return settings.GetEnvVars(ctx, stack, keys...)
+}
+
+func GetResourceRequirements(ctx core.Context, stack string, keys ...string) (*corev1.ResourceRequirements, error) {
+	return settings.GetResourceRequirements(ctx, stack, keys...)
+}
+
+func GetResourceList(ctx core.Context, stack string, keys ...string) (corev1.ResourceList, error) {
+	return settings.GetResourceList(ctx, stack, keys...)
+}
+
+func GetAs[T any](ctx core.Context, stack string, keys ...string) (*T, error) {
+	return settings.GetAs[T](ctx, stack, keys...)
+}
+
+// GetOTELEnvVars returns the OpenTelemetry environment variables configured for the stack
+func GetOTELEnvVars(ctx core.Context, stack, serviceName, sliceStringSeparator string) ([]corev1.EnvVar, error) {
+	return settings.GetOTELEnvVars(ctx, stack, serviceName, sliceStringSeparator)
+}
+
+// GetAWSServiceAccount returns the service account configured to access AWS services
+func GetAWSServiceAccount(ctx core.Context, stack string) (string, error) {
+	return settings.GetAWSServiceAccount(ctx, stack)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func NewCloneCommand(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	ret := &cobra.Command{
+		Use:   "clone <source-stack> <destination-stack>",
+		Short: "Create a new stack identical to an existing one",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getRestClient(configFlags)
+			if err != nil {
+				return err
+			}
+
+			copyDatabases, err := cmd.Flags().GetBool("copy-databases")
+			if err != nil {
+				return err
+			}
+
+			regenerateAuthSecrets, err := cmd.Flags().GetBool("regenerate-auth-secrets")
+			if err != nil {
+				return err
+			}
+
+			stackClone := v1beta1.StackClone{
+				ObjectMeta: v1.ObjectMeta{
+					Name: fmt.Sprintf("%s-to-%s", args[0], args[1]),
+				},
+				Spec: v1beta1.StackCloneSpec{
+					Source:                args[0],
+					Destination:           args[1],
+					CopyDatabases:         copyDatabases,
+					RegenerateAuthSecrets: regenerateAuthSecrets,
+				},
+			}
+			stackClone.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("StackClone"))
+
+			data, err := json.Marshal(stackClone)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Clone stack '%s' to '%s'...\r\n", args[0], args[1])
+
+			return client.Post().
+				Resource("StackClones").
+				Body(data).
+				Do(cmd.Context()).
+				Error()
+		},
+	}
+
+	ret.Flags().Bool("copy-databases", false, "Copy the content of the databases of the source stack")
+	ret.Flags().Bool("regenerate-auth-secrets", false, "Generate new secrets for the auth clients and a new signing key")
+
+	return ret
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func NewExportCommand(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	ret := &cobra.Command{
+		Use:   "export <stack-name>",
+		Short: "Export a stack, its modules, its resources and its settings as a YAML bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, mapper, err := getDynamicClient(configFlags)
+			if err != nil {
+				return err
+			}
+
+			resolveSettings, err := cmd.Flags().GetBool("resolve-settings")
+			if err != nil {
+				return err
+			}
+
+			output, err := cmd.Flags().GetString("output-file")
+			if err != nil {
+				return err
+			}
+
+			objects, err := export(cmd.Context(), client, mapper, args[0], resolveSettings)
+			if err != nil {
+				return err
+			}
+
+			data := make([]byte, 0)
+			for _, object := range objects {
+				document, err := yaml.Marshal(object.Object)
+				if err != nil {
+					return err
+				}
+				data = append(data, []byte("---\n")...)
+				data = append(data, document...)
+			}
+
+			if output == "" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+
+			return os.WriteFile(output, data, 0600)
+		},
+	}
+
+	ret.Flags().Bool("resolve-settings", false, "Include the settings applied to all stacks, when not overridden by a setting of the stack")
+	ret.Flags().StringP("output-file", "f", "", "Write the bundle to a file instead of the standard output")
+
+	return ret
+}
+
+// export returns the objects describing a stack: the referenced Versions, the Settings, the Stack,
+// then the modules and the resources of the stack.
+// Objects controlled by another object are created by the operator and are not exported.
+func export(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, stackName string, resolveSettings bool) ([]*unstructured.Unstructured, error) {
+	stack, err := getObject(ctx, client, mapper, "Stack", stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*unstructured.Unstructured, 0)
+
+	versionsFromFile, _, err := unstructured.NestedString(stack.Object, "spec", "versionsFromFile")
+	if err != nil {
+		return nil, err
+	}
+	if versionsFromFile != "" {
+		versions, err := getObject(ctx, client, mapper, "Versions", versionsFromFile)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, versions)
+	}
+
+	settings, err := exportSettings(ctx, client, mapper, stackName, resolveSettings)
+	if err != nil {
+		return nil, err
+	}
+	ret = append(ret, settings...)
+	ret = append(ret, stack)
+
+	kinds := make([]string, 0)
+	for kind, rtype := range scheme.Scheme.KnownTypes(v1beta1.GroupVersion) {
+		switch reflect.New(rtype).Interface().(type) {
+		case v1beta1.Module, v1beta1.Resource:
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		list, err := listObjects(ctx, client, mapper, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			if metav1.GetControllerOf(&item) != nil {
+				continue
+			}
+			if stack, _, _ := unstructured.NestedString(item.Object, "spec", "stack"); stack != stackName {
+				continue
+			}
+			ret = append(ret, &item)
+		}
+	}
+
+	for _, object := range ret {
+		cleanObject(object)
+	}
+
+	return ret, nil
+}
+
+// exportSettings returns the settings explicitly targeting the stack.
+// With resolve, the settings targeting all stacks are also returned, unless a setting of the stack uses the same key.
+// Exported settings only target the exported stack.
+func exportSettings(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, stackName string, resolve bool) ([]*unstructured.Unstructured, error) {
+	list, err := listObjects(ctx, client, mapper, "Settings")
+	if err != nil {
+		return nil, err
+	}
+
+	explicit := make([]*unstructured.Unstructured, 0)
+	wildcards := make([]*unstructured.Unstructured, 0)
+	for _, item := range list.Items {
+		stacks, _, err := unstructured.NestedStringSlice(item.Object, "spec", "stacks")
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case slices.Contains(stacks, stackName):
+			explicit = append(explicit, &item)
+		case slices.Contains(stacks, "*") && resolve:
+			wildcards = append(wildcards, &item)
+		}
+	}
+
+	keys := make([]string, 0, len(explicit))
+	for _, settings := range explicit {
+		key, _, _ := unstructured.NestedString(settings.Object, "spec", "key")
+		keys = append(keys, key)
+	}
+
+	ret := explicit
+	for _, settings := range wildcards {
+		key, _, _ := unstructured.NestedString(settings.Object, "spec", "key")
+		if slices.Contains(keys, key) {
+			continue
+		}
+		ret = append(ret, settings)
+	}
+
+	for _, settings := range ret {
+		if err := unstructured.SetNestedStringSlice(settings.Object, []string{stackName}, "spec", "stacks"); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// cleanObject removes the status and the metadata set by the cluster
+func cleanObject(object *unstructured.Unstructured) {
+	delete(object.Object, "status")
+	for _, field := range []string{
+		"uid",
+		"resourceVersion",
+		"generation",
+		"creationTimestamp",
+		"deletionTimestamp",
+		"deletionGracePeriodSeconds",
+		"managedFields",
+		"ownerReferences",
+		"finalizers",
+		"selfLink",
+	} {
+		unstructured.RemoveNestedField(object.Object, "metadata", field)
+	}
+
+	annotations := object.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	object.SetAnnotations(annotations)
+}
+
+func getObject(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, kind, name string) (*unstructured.Unstructured, error) {
+	mapping, err := mapper.RESTMapping(v1beta1.GroupVersion.WithKind(kind).GroupKind(), v1beta1.GroupVersion.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := client.Resource(mapping.Resource).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting %s '%s': %w", kind, name, err)
+	}
+
+	return ret, nil
+}
+
+func listObjects(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, kind string) (*unstructured.UnstructuredList, error) {
+	mapping, err := mapper.RESTMapping(v1beta1.GroupVersion.WithKind(kind).GroupKind(), v1beta1.GroupVersion.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := client.Resource(mapping.Resource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", kind, err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/formancehq/operator/v3/api/formance.com/v1beta1"
+)
+
+func newSettings(name, key string, stacks ...any) *unstructured.Unstructured {
+	return newObject("Settings", name, map[string]any{
+		"key":    key,
+		"stacks": stacks,
+		"value":  "true",
+	})
+}
+
+func TestExportSettings(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		settings      []*unstructured.Unstructured
+		resolve       bool
+		expectedNames []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "settings of the stack",
+			settings: []*unstructured.Unstructured{
+				newSettings("acme-debug", "debug", "acme"),
+				newSettings("shared-debug", "debug", "acme", "other"),
+				newSettings("other-debug", "debug", "other"),
+			},
+			expectedNames: []string{"acme-debug", "shared-debug"},
+		},
+		{
+			name: "wildcard settings without resolve",
+			settings: []*unstructured.Unstructured{
+				newSettings("all-debug", "debug", "*"),
+			},
+			expectedNames: []string{},
+		},
+		{
+			name: "wildcard settings with resolve",
+			settings: []*unstructured.Unstructured{
+				newSettings("all-debug", "debug", "*"),
+				newSettings("other-dev", "dev", "other"),
+			},
+			resolve:       true,
+			expectedNames: []string{"all-debug"},
+		},
+		{
+			name: "wildcard settings overridden by the stack",
+			settings: []*unstructured.Unstructured{
+				newSettings("acme-debug", "debug", "acme"),
+				newSettings("all-debug", "debug", "*"),
+				newSettings("all-dev", "dev", "*"),
+			},
+			resolve:       true,
+			expectedNames: []string{"acme-debug", "all-dev"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gvk := v1beta1.GroupVersion.WithKind("Settings")
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{v1beta1.GroupVersion})
+			mapper.Add(gvk, meta.RESTScopeRoot)
+			mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			require.NoError(t, err)
+
+			objects := make([]runtime.Object, 0, len(tc.settings))
+			for _, settings := range tc.settings {
+				objects = append(objects, settings)
+			}
+			client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				mapping.Resource: "SettingsList",
+			}, objects...)
+
+			settings, err := exportSettings(context.Background(), client, mapper, "acme", tc.resolve)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(settings))
+			for _, item := range settings {
+				names = append(names, item.GetName())
+
+				// Exported settings only target the exported stack
+				stacks, _, err := unstructured.NestedStringSlice(item.Object, "spec", "stacks")
+				require.NoError(t, err)
+				require.Equal(t, []string{"acme"}, stacks)
+			}
+			require.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestCleanObject(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		object   map[string]any
+		expected map[string]any
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "server populated metadata and status",
+			object: map[string]any{
+				"kind": "Ledger",
+				"metadata": map[string]any{
+					"name":                       "acme-ledger",
+					"labels":                     map[string]any{"team": "core"},
+					"uid":                        "7b2c",
+					"resourceVersion":            "42",
+					"generation":                 int64(3),
+					"creationTimestamp":          "2024-01-01T00:00:00Z",
+					"deletionTimestamp":          "2024-01-02T00:00:00Z",
+					"deletionGracePeriodSeconds": int64(0),
+					"managedFields":              []any{map[string]any{"manager": "kubectl"}},
+					"ownerReferences":            []any{map[string]any{"name": "acme"}},
+					"finalizers":                 []any{"formance.com/finalizer"},
+					"selfLink":                   "/apis/formance.com/v1beta1/ledgers/acme-ledger",
+				},
+				"spec":   map[string]any{"stack": "acme"},
+				"status": map[string]any{"ready": true},
+			},
+			expected: map[string]any{
+				"kind": "Ledger",
+				"metadata": map[string]any{
+					"name":   "acme-ledger",
+					"labels": map[string]any{"team": "core"},
+				},
+				"spec": map[string]any{"stack": "acme"},
+			},
+		},
+		{
+			name: "last applied configuration",
+			object: map[string]any{
+				"kind": "Stack",
+				"metadata": map[string]any{
+					"name": "acme",
+					"annotations": map[string]any{
+						corev1.LastAppliedConfigAnnotation: "{}",
+						"owner":                            "core",
+					},
+				},
+			},
+			expected: map[string]any{
+				"kind": "Stack",
+				"metadata": map[string]any{
+					"name": "acme",
+					"annotations": map[string]any{
+						"owner": "core",
+					},
+				},
+			},
+		},
+		{
+			name: "only annotation is the last applied configuration",
+			object: map[string]any{
+				"kind": "Stack",
+				"metadata": map[string]any{
+					"name": "acme",
+					"annotations": map[string]any{
+						corev1.LastAppliedConfigAnnotation: "{}",
+					},
+				},
+			},
+			expected: map[string]any{
+				"kind": "Stack",
+				"metadata": map[string]any{
+					"name": "acme",
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			object := &unstructured.Unstructured{Object: tc.object}
+			cleanObject(object)
+			require.Equal(t, tc.expected, object.Object)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func NewImportCommand(configFlags *genericclioptions.ConfigFlags) *cobra.Command {
+	ret := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Create a stack from a bundle created with the export command, use '-' to read the standard input",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, mapper, err := getDynamicClient(configFlags)
+			if err != nil {
+				return err
+			}
+
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+
+			var reader io.Reader = cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = f.Close()
+				}()
+				reader = f
+			}
+
+			objects, err := readBundle(reader)
+			if err != nil {
+				return err
+			}
+
+			if name != "" {
+				if err := renameStack(objects, name); err != nil {
+					return err
+				}
+			}
+
+			for _, object := range objects {
+				mapping, err := mapper.RESTMapping(object.GroupVersionKind().GroupKind(), object.GroupVersionKind().Version)
+				if err != nil {
+					return err
+				}
+
+				_, err = client.Resource(mapping.Resource).Create(cmd.Context(), object, metav1.CreateOptions{})
+				switch {
+				case apierrors.IsAlreadyExists(err):
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s '%s' already exists, skipped\r\n", object.GetKind(), object.GetName())
+				case err != nil:
+					return fmt.Errorf("creating %s '%s': %w", object.GetKind(), object.GetName(), err)
+				default:
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s '%s' created\r\n", object.GetKind(), object.GetName())
+				}
+			}
+
+			return nil
+		},
+	}
+
+	ret.Flags().String("name", "", "Import the stack under a new name")
+
+	return ret
+}
+
+func readBundle(reader io.Reader) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bufio.NewReader(reader), 4096)
+	ret := make([]*unstructured.Unstructured, 0)
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ret, nil
+			}
+			return nil, err
+		}
+		if len(object.Object) == 0 {
+			continue
+		}
+		ret = append(ret, object)
+	}
+}
+
+// renameStack renames the stack of the bundle and the objects depending on it.
+// Like for a StackClone, object names starting with the name of the stack are renamed accordingly,
+// and other names are prefixed with the new name of the stack.
+// Versions objects are shared between stacks and are left untouched.
+func renameStack(objects []*unstructured.Unstructured, name string) error {
+	var previousName string
+	for _, object := range objects {
+		if object.GetKind() != "Stack" {
+			continue
+		}
+		if previousName != "" {
+			return errors.New("the bundle contains more than one stack")
+		}
+		previousName = object.GetName()
+	}
+	if previousName == "" {
+		return errors.New("the bundle does not contain any stack")
+	}
+
+	rename := func(objectName string) string {
+		if objectName == previousName {
+			return name
+		}
+		if strings.HasPrefix(objectName, previousName+"-") {
+			return name + strings.TrimPrefix(objectName, previousName)
+		}
+		return fmt.Sprintf("%s-%s", name, objectName)
+	}
+
+	for _, object := range objects {
+		switch object.GetKind() {
+		case "Versions":
+			continue
+		case "Settings":
+			if err := unstructured.SetNestedStringSlice(object.Object, []string{name}, "spec", "stacks"); err != nil {
+				return err
+			}
+		case "Stack":
+		default:
+			if err := unstructured.SetNestedField(object.Object, name, "spec", "stack"); err != nil {
+				return err
+			}
+		}
+		object.SetName(rename(object.GetName()))
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newObject(kind, name string, spec map[string]any) *unstructured.Unstructured {
+	ret := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "formance.com/v1beta1",
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name": name,
+		},
+	}}
+	if spec != nil {
+		ret.Object["spec"] = spec
+	}
+	return ret
+}
+
+func TestRenameStack(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		objects       []*unstructured.Unstructured
+		expectedNames []string
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "stack renamed",
+			objects: []*unstructured.Unstructured{
+				newObject("Stack", "acme", nil),
+			},
+			expectedNames: []string{"staging"},
+		},
+		{
+			name: "names starting with the stack name",
+			objects: []*unstructured.Unstructured{
+				newObject("Stack", "acme", nil),
+				newObject("Ledger", "acme-ledger", map[string]any{"stack": "acme"}),
+			},
+			expectedNames: []string{"staging", "staging-ledger"},
+		},
+		{
+			name: "other names prefixed",
+			objects: []*unstructured.Unstructured{
+				newObject("Stack", "acme", nil),
+				newObject("Ledger", "ledger", map[string]any{"stack": "acme"}),
+				newObject("Ledger", "acmeledger", map[string]any{"stack": "acme"}),
+			},
+			expectedNames: []string{"staging", "staging-ledger", "staging-acmeledger"},
+		},
+		{
+			name: "versions untouched",
+			objects: []*unstructured.Unstructured{
+				newObject("Versions", "acme-versions", nil),
+				newObject("Stack", "acme", nil),
+			},
+			expectedNames: []string{"acme-versions", "staging"},
+		},
+		{
+			name: "settings renamed",
+			objects: []*unstructured.Unstructured{
+				newObject("Settings", "acme-debug", map[string]any{"stacks": []any{"acme"}}),
+				newObject("Stack", "acme", nil),
+			},
+			expectedNames: []string{"staging-debug", "staging"},
+		},
+		{
+			name: "no stack",
+			objects: []*unstructured.Unstructured{
+				newObject("Ledger", "acme-ledger", map[string]any{"stack": "acme"}),
+			},
+			expectedError: "the bundle does not contain any stack",
+		},
+		{
+			name: "more than one stack",
+			objects: []*unstructured.Unstructured{
+				newObject("Stack", "acme", nil),
+				newObject("Stack", "other", nil),
+			},
+			expectedError: "the bundle contains more than one stack",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := renameStack(tc.objects, "staging")
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(tc.objects))
+			for _, object := range tc.objects {
+				names = append(names, object.GetName())
+
+				switch object.GetKind() {
+				case "Settings":
+					stacks, _, err := unstructured.NestedStringSlice(object.Object, "spec", "stacks")
+					require.NoError(t, err)
+					require.Equal(t, []string{"staging"}, stacks)
+				case "Stack", "Versions":
+				default:
+					stack, _, err := unstructured.NestedString(object.Object, "spec", "stack")
+					require.NoError(t, err)
+					require.Equal(t, "staging", stack)
+				}
+			}
+			require.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
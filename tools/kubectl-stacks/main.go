@@ -6,7 +6,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 
@@ -28,8 +30,13 @@ func NewRootCommand() *cobra.Command {
 		NewSetDebugCommand(configFlags),
 		NewDisableCommand(configFlags),
 		NewEnableCommand(configFlags),
+		NewHibernateCommand(configFlags),
+		NewWakeCommand(configFlags),
 		NewUpgradeCommand(configFlags),
 		NewSettingsCommand(configFlags),
+		NewCloneCommand(configFlags),
+		NewExportCommand(configFlags),
+		NewImportCommand(configFlags),
 	)
 
 	return cmd
@@ -47,6 +54,25 @@ func getRestClient(configFlags *genericclioptions.ConfigFlags) (*rest.RESTClient
 	return rest.RESTClientFor(restConfig)
 }
 
+func getDynamicClient(configFlags *genericclioptions.ConfigFlags) (dynamic.Interface, meta.RESTMapper, error) {
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapper, err := configFlags.ToRESTMapper()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, mapper, nil
+}
+
 func main() {
 	flags := pflag.NewFlagSet("kubectl-stacks", pflag.ExitOnError)
 	pflag.CommandLine = flags